@@ -6,5 +6,7 @@ import (
 )
 
 func main() {
+	defer nats.CloseConnections()
+
 	plugin.Serve(&plugin.ServeOpts{PluginFunc: nats.Plugin})
 }
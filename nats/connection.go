@@ -16,17 +16,45 @@ func String(s *string) string {
 	return *s
 }
 
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func stringIn(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 type natsConfig struct {
-	Context       *string `cty:"context"`
-	URLs          *string `cty:"urls"`
-	MonitoringURL *string `cty:"monitoring_url"`
-	Creds         *string `cty:"creds"`
-	Nkey          *string `cty:"nkey"`
-	Username      *string `cty:"username"`
-	Password      *string `cty:"password"`
-	TLSCert       *string `cty:"tlscert"`
-	TLSKey        *string `cty:"tlskey"`
-	TLSCACert     *string `cty:"tlscacert"`
+	Context             *string   `cty:"context"`
+	URLs                *string   `cty:"urls"`
+	MonitoringURL       *string   `cty:"monitoring_url"`
+	MonitoringURLs      *[]string `cty:"monitoring_urls"`
+	MonitoringSource    *string   `cty:"monitoring_source"`
+	MonitoringBasePath  *string   `cty:"monitoring_base_path"`
+	MonitoringTLSCert   *string   `cty:"monitoring_tlscert"`
+	MonitoringTLSKey    *string   `cty:"monitoring_tlskey"`
+	MonitoringTLSCACert *string   `cty:"monitoring_tlscacert"`
+	MonitoringUsername  *string   `cty:"monitoring_username"`
+	MonitoringPassword  *string   `cty:"monitoring_password"`
+	MonitoringToken     *string   `cty:"monitoring_token"`
+	MonitoringHeaders   *[]string `cty:"monitoring_headers"`
+	MonitoringTimeout   *string   `cty:"monitoring_timeout"`
+	Creds               *string   `cty:"creds"`
+	Nkey                *string   `cty:"nkey"`
+	Username            *string   `cty:"username"`
+	Password            *string   `cty:"password"`
+	TLSCert             *string   `cty:"tlscert"`
+	TLSKey              *string   `cty:"tlskey"`
+	TLSCACert           *string   `cty:"tlscacert"`
+	MaxConcurrency      *int      `cty:"max_concurrency"`
 }
 
 func (c *natsConfig) Connect() (*nats.Conn, error) {
@@ -43,6 +71,15 @@ func (c *natsConfig) Connect() (*nats.Conn, error) {
 	return nats.Connect(String(c.URLs), opts...)
 }
 
+// maxConcurrency is the size of the worker pool used to fan out JetStream API
+// requests within a single query.
+func (c *natsConfig) maxConcurrency() int {
+	if c.MaxConcurrency == nil || *c.MaxConcurrency < 1 {
+		return defaultMaxConcurrency
+	}
+	return *c.MaxConcurrency
+}
+
 func connConfig() interface{} {
 	return &natsConfig{}
 }
@@ -57,6 +94,41 @@ var configSchema = map[string]*schema.Attribute{
 	"monitoring_url": {
 		Type: schema.TypeString,
 	},
+	"monitoring_urls": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"monitoring_source": {
+		Type: schema.TypeString,
+	},
+	"monitoring_base_path": {
+		Type: schema.TypeString,
+	},
+	"monitoring_tlscert": {
+		Type: schema.TypeString,
+	},
+	"monitoring_tlskey": {
+		Type: schema.TypeString,
+	},
+	"monitoring_tlscacert": {
+		Type: schema.TypeString,
+	},
+	"monitoring_username": {
+		Type: schema.TypeString,
+	},
+	"monitoring_password": {
+		Type: schema.TypeString,
+	},
+	"monitoring_token": {
+		Type: schema.TypeString,
+	},
+	"monitoring_headers": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"monitoring_timeout": {
+		Type: schema.TypeString,
+	},
 	"creds": {
 		Type: schema.TypeString,
 	},
@@ -78,6 +150,9 @@ var configSchema = map[string]*schema.Attribute{
 	"tlscacert": {
 		Type: schema.TypeString,
 	},
+	"max_concurrency": {
+		Type: schema.TypeInt,
+	},
 }
 
 func GetConfig(conn *plugin.Connection) (*natsConfig, error) {
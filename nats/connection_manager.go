@@ -0,0 +1,237 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// natsConnection is a NATS connection and the JetStream clients built on top
+// of it.
+type natsConnection struct {
+	nc      *nats.Conn
+	manager *jsm.Manager
+	js      nats.JetStreamContext
+}
+
+// connectionManager caches one natsConnection and one monitoringSource per
+// Steampipe connection name so hydrate functions share a single client
+// instead of dialing on every call. mu only guards the maps; dials hold the
+// lock of their connection name in dialing, so an unreachable server only
+// holds up queries on its own connection. Closing a name bumps its generation
+// so a dial that was in flight does not cache a client with the old config.
+type connectionManager struct {
+	mu          sync.Mutex
+	conns       map[string]*natsConnection
+	monitors    map[string]monitoringSource
+	dialing     map[string]*sync.Mutex
+	generations map[string]uint64
+}
+
+var connections = newConnectionManager()
+
+func newConnectionManager() *connectionManager {
+	return &connectionManager{
+		conns:       map[string]*natsConnection{},
+		monitors:    map[string]monitoringSource{},
+		dialing:     map[string]*sync.Mutex{},
+		generations: map[string]uint64{},
+	}
+}
+
+// cached returns the open connection cached for name, forgetting it when it
+// was closed after exhausting its reconnects.
+func (m *connectionManager) cached(name string) (*natsConnection, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.conns[name]
+	if !ok {
+		return nil, false
+	}
+	if c.nc.IsClosed() {
+		delete(m.conns, name)
+		return nil, false
+	}
+
+	return c, true
+}
+
+// dialLock returns the lock serializing dials for name.
+func (m *connectionManager) dialLock(name string) *sync.Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, ok := m.dialing[name]
+	if !ok {
+		l = &sync.Mutex{}
+		m.dialing[name] = l
+	}
+
+	return l
+}
+
+// Get returns the cached connection for conn, dialing a new one if there is
+// none yet or the previous one was closed after exhausting its reconnects.
+func (m *connectionManager) Get(conn *plugin.Connection) (*natsConnection, error) {
+	if c, ok := m.cached(conn.Name); ok {
+		return c, nil
+	}
+
+	l := m.dialLock(conn.Name)
+	l.Lock()
+	defer l.Unlock()
+
+	// Another query may have dialed while this one waited for the lock.
+	if c, ok := m.cached(conn.Name); ok {
+		return c, nil
+	}
+
+	m.mu.Lock()
+	generation := m.generations[conn.Name]
+	m.mu.Unlock()
+
+	config, err := GetConfig(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	nc, err := config.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := jsm.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+
+	js, err := nc.JetStream()
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+
+	c := &natsConnection{
+		nc:      nc,
+		manager: manager,
+		js:      js,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.generations[conn.Name] != generation {
+		nc.Close()
+		return nil, fmt.Errorf("connection %s was closed while connecting", conn.Name)
+	}
+	m.conns[conn.Name] = c
+
+	return c, nil
+}
+
+// Monitor returns the cached monitoring source for conn, creating it on first
+// use.
+func (m *connectionManager) Monitor(conn *plugin.Connection) (monitoringSource, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.monitors[conn.Name]; ok {
+		return c, nil
+	}
+
+	config, err := GetConfig(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := newMonitoringSource(config, func() (*nats.Conn, error) {
+		nc, err := m.Get(conn)
+		if err != nil {
+			return nil, err
+		}
+		return nc.nc, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.monitors[conn.Name] = c
+
+	return c, nil
+}
+
+// Close closes and forgets the clients for the named Steampipe connection.
+func (m *connectionManager) Close(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.conns[name]; ok {
+		c.nc.Close()
+		delete(m.conns, name)
+	}
+	if c, ok := m.monitors[name]; ok {
+		c.close()
+		delete(m.monitors, name)
+	}
+	m.generations[name]++
+}
+
+// CloseAll closes every cached client.
+func (m *connectionManager) CloseAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, c := range m.conns {
+		c.nc.Close()
+		delete(m.conns, name)
+	}
+	for name, c := range m.monitors {
+		c.close()
+		delete(m.monitors, name)
+	}
+	for name := range m.dialing {
+		m.generations[name]++
+	}
+}
+
+// CloseConnections closes all NATS connections held by the plugin. It is
+// called once the plugin server has stopped.
+func CloseConnections() {
+	connections.CloseAll()
+}
+
+func getManager(d *plugin.QueryData) (*jsm.Manager, error) {
+	c, err := connections.Get(d.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.manager, nil
+}
+
+func getJetStream(d *plugin.QueryData) (nats.JetStreamContext, error) {
+	c, err := connections.Get(d.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.js, nil
+}
+
+func getMonitor(d *plugin.QueryData) (monitoringSource, error) {
+	return connections.Monitor(d.Connection)
+}
+
+// connectionConfigChanged drops the cached connection so the next query dials
+// with the new settings, then clears the SDK caches like the default handler.
+func connectionConfigChanged(ctx context.Context, p *plugin.Plugin, old, new *plugin.Connection) error {
+	connections.Close(old.Name)
+	p.ClearConnectionCache(ctx, new.Name)
+	p.ClearQueryCache(ctx, new.Name)
+	return nil
+}
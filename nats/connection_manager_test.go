@@ -0,0 +1,117 @@
+package nats
+
+import (
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// slowServer accepts NATS clients and only connects them to target after
+// delay, or never when target is empty, which keeps their dial in flight.
+func slowServer(t *testing.T, delay time.Duration, target string) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var conns []net.Conn
+	track := func(c net.Conn) {
+		mu.Lock()
+		conns = append(conns, c)
+		mu.Unlock()
+	}
+	t.Cleanup(func() {
+		l.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, c := range conns {
+			c.Close()
+		}
+	})
+
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			track(c)
+			if target == "" {
+				continue
+			}
+
+			go func() {
+				time.Sleep(delay)
+				s, err := net.Dial("tcp", target)
+				if err != nil {
+					c.Close()
+					return
+				}
+				track(s)
+				go io.Copy(s, c)
+				io.Copy(c, s)
+			}()
+		}
+	}()
+
+	return "nats://" + l.Addr().String()
+}
+
+// testPluginConnection is a plugin connection to url as the app user.
+func testPluginConnection(name, url string) *plugin.Connection {
+	user, password := "app", "app"
+	return &plugin.Connection{Name: name, Config: natsConfig{URLs: &url, Username: &user, Password: &password}}
+}
+
+func TestConnectionManagerDialsOutsideLock(t *testing.T) {
+	m := newConnectionManager()
+	defer m.CloseAll()
+
+	go m.Get(testPluginConnection("stuck", slowServer(t, 0, "")))
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	if _, err := m.Get(testPluginConnection("healthy", testServer.ClientURL())); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the healthy connection not to wait for the stuck dial, took %v", elapsed)
+	}
+}
+
+func TestConnectionManagerCloseWhileDialing(t *testing.T) {
+	m := newConnectionManager()
+	defer m.CloseAll()
+
+	slow := testPluginConnection("changed", slowServer(t, 300*time.Millisecond, testServer.Addr().String()))
+	dialed := make(chan error, 1)
+	go func() {
+		_, err := m.Get(slow)
+		dialed <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	// The config changes while the first dial is still in flight.
+	m.Close("changed")
+	c, err := m.Get(testPluginConnection("changed", testServer.ClientURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-dialed; err == nil {
+		t.Error("expected the dial with the old config to fail")
+	}
+	if cached, ok := m.cached("changed"); !ok || cached != c {
+		t.Error("expected the connection with the new config to be cached")
+	}
+	if c.nc.ConnectedUrl() != testServer.ClientURL() {
+		t.Errorf("expected a connection to %s, got %s", testServer.ClientURL(), c.nc.ConnectedUrl())
+	}
+}
@@ -0,0 +1,74 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// consumerListKeyColumns are the optional quals eachConsumer pushes down.
+func consumerListKeyColumns() plugin.KeyColumnSlice {
+	return plugin.OptionalColumns([]string{"stream", "name"})
+}
+
+// eachConsumer calls cb for every consumer matching the stream and name quals
+// of the query. Only streams named by a stream qual are visited, and consumers
+// named by a name qual are loaded directly rather than listed. Streams are
+// queried in parallel while cb is always called from the calling goroutine.
+func eachConsumer(ctx context.Context, d *plugin.QueryData, manager *jsm.Manager, cb func(*jsm.Consumer) error) error {
+	config, err := GetConfig(d.Connection)
+	if err != nil {
+		return err
+	}
+
+	streams := qualStrings(d, "stream")
+	if streams == nil {
+		streams, err = manager.StreamNames(nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	names := qualStrings(d, "name")
+
+	load := func(s string) ([]*jsm.Consumer, error) {
+		if names == nil {
+			consumers, err := manager.Consumers(s)
+			if isNotFound(err) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, streamError(s, err)
+			}
+			return consumers, nil
+		}
+
+		var consumers []*jsm.Consumer
+		for _, n := range names {
+			consumer, err := manager.LoadConsumer(s, n)
+			if isNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, consumerError(s, n, err)
+			}
+			consumers = append(consumers, consumer)
+		}
+
+		return consumers, nil
+	}
+
+	var cbErr error
+	err = fanOut(ctx, config.maxConcurrency(), streams, load, func(c *jsm.Consumer) bool {
+		if cbErr = cb(c); cbErr != nil {
+			return false
+		}
+		return d.QueryStatus.RowsRemaining(ctx) > 0
+	})
+	if err != nil {
+		return err
+	}
+
+	return cbErr
+}
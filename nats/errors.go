@@ -0,0 +1,86 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// jsErrorCode returns the JetStream API error code carried by err, or 0 when
+// err did not come from the JetStream API.
+func jsErrorCode(err error) nats.ErrorCode {
+	var apiErr api.ApiError
+	if errors.As(err, &apiErr) {
+		return nats.ErrorCode(apiErr.NatsErrorCode())
+	}
+
+	var apiErrPtr *api.ApiError
+	if errors.As(err, &apiErrPtr) {
+		return nats.ErrorCode(apiErrPtr.NatsErrorCode())
+	}
+
+	var jsErr nats.JetStreamError
+	if errors.As(err, &jsErr) && jsErr.APIError() != nil {
+		return jsErr.APIError().ErrorCode
+	}
+
+	return 0
+}
+
+// isNotFound reports whether err means the stream, consumer, message, bucket
+// or key a query asked for does not exist.
+func isNotFound(err error) bool {
+	switch jsErrorCode(err) {
+	case nats.JSErrCodeStreamNotFound, nats.JSErrCodeConsumerNotFound, nats.JSErrCodeMessageNotFound:
+		return true
+	}
+
+	return errors.Is(err, nats.ErrBucketNotFound) ||
+		errors.Is(err, nats.ErrKeyNotFound) ||
+		errors.Is(err, nats.ErrObjectNotFound)
+}
+
+// isJetStreamDisabled reports whether err means JetStream is not available to
+// the connected account.
+func isJetStreamDisabled(err error) bool {
+	switch jsErrorCode(err) {
+	case nats.JSErrCodeJetStreamNotEnabled, nats.JSErrCodeJetStreamNotEnabledForAccount:
+		return true
+	}
+
+	return false
+}
+
+// shouldIgnoreError turns not found errors into empty results, so a query or
+// join naming a missing asset returns zero rows instead of failing.
+func shouldIgnoreError(_ context.Context, _ *plugin.QueryData, _ *plugin.HydrateData, err error) bool {
+	return isNotFound(err)
+}
+
+func streamError(stream string, err error) error {
+	if isJetStreamDisabled(err) {
+		return fmt.Errorf("stream %s: JetStream is not enabled for this account: %w", stream, err)
+	}
+
+	return fmt.Errorf("stream %s: %w", stream, err)
+}
+
+func consumerError(stream, consumer string, err error) error {
+	if isJetStreamDisabled(err) {
+		return fmt.Errorf("consumer %s > %s: JetStream is not enabled for this account: %w", stream, consumer, err)
+	}
+
+	return fmt.Errorf("consumer %s > %s: %w", stream, consumer, err)
+}
+
+func bucketError(bucket string, err error) error {
+	if isJetStreamDisabled(err) {
+		return fmt.Errorf("bucket %s: JetStream is not enabled for this account: %w", bucket, err)
+	}
+
+	return fmt.Errorf("bucket %s: %w", bucket, err)
+}
@@ -0,0 +1,39 @@
+package nats
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+)
+
+func TestIsNotFound(t *testing.T) {
+	cases := []struct {
+		err      error
+		notFound bool
+	}{
+		{api.ApiError{Code: 404, ErrCode: uint16(nats.JSErrCodeStreamNotFound)}, true},
+		{&api.ApiError{Code: 404, ErrCode: uint16(nats.JSErrCodeConsumerNotFound)}, true},
+		{streamError("ORDERS", api.ApiError{Code: 404, ErrCode: uint16(nats.JSErrCodeStreamNotFound)}), true},
+		{nats.ErrStreamNotFound, true},
+		{fmt.Errorf("bucket: %w", nats.ErrBucketNotFound), true},
+		{api.ApiError{Code: 503, ErrCode: uint16(nats.JSErrCodeJetStreamNotEnabledForAccount)}, false},
+		{nats.ErrTimeout, false},
+	}
+
+	for _, c := range cases {
+		if got := isNotFound(c.err); got != c.notFound {
+			t.Errorf("isNotFound(%v) = %t, expected %t", c.err, got, c.notFound)
+		}
+	}
+}
+
+func TestIsJetStreamDisabled(t *testing.T) {
+	if !isJetStreamDisabled(consumerError("ORDERS", "PROCESSOR", nats.ErrJetStreamNotEnabled)) {
+		t.Error("expected wrapped ErrJetStreamNotEnabled to be classified as disabled")
+	}
+	if isJetStreamDisabled(nats.ErrStreamNotFound) {
+		t.Error("expected ErrStreamNotFound not to be classified as disabled")
+	}
+}
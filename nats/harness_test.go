@@ -0,0 +1,468 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// testConnection uses the APP account and the HTTP monitoring source.
+	testConnection = "nats_test"
+	// testSystemConnection uses the SYS account and the $SYS monitoring source.
+	testSystemConnection = "nats_test_system"
+)
+
+var (
+	testServer *server.Server
+	testPlugin *plugin.Plugin
+)
+
+// TestMain starts an in-process JetStream server with its monitoring port and
+// a system account enabled, creates the fixtures every table test relies on
+// and configures a plugin instance with a connection for each account.
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	storeDir, err := os.MkdirTemp("", "steampipe-plugin-nats")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer os.RemoveAll(storeDir)
+
+	testServer, err = startServer(storeDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer testServer.Shutdown()
+
+	if err := createFixtures(testServer.ClientURL()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	testPlugin, err = newTestPlugin(map[string]string{
+		testConnection: fmt.Sprintf(`
+urls           = %q
+username       = "app"
+password       = "app"
+monitoring_url = %q
+`, testServer.ClientURL(), testMonitoringURL()),
+		testSystemConnection: fmt.Sprintf(`
+urls               = %q
+username           = "sys"
+password           = "sys"
+monitoring_source  = "system"
+monitoring_timeout = "250ms"
+`, testServer.ClientURL()),
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer CloseConnections()
+
+	return m.Run()
+}
+
+const testServerConfig = `
+server_name: test-server
+listen: 127.0.0.1:-1
+http: 127.0.0.1:-1
+jetstream {
+  store_dir: %q
+}
+accounts {
+  APP {
+    jetstream: enabled
+    users: [{user: app, password: app}]
+  }
+  SYS {
+    users: [{user: sys, password: sys}]
+  }
+}
+system_account: SYS
+`
+
+func startServer(storeDir string) (*server.Server, error) {
+	return startServerConfig(storeDir, fmt.Sprintf(testServerConfig, storeDir))
+}
+
+// startServerConfig starts a server from config, which is written to dir.
+func startServerConfig(dir, config string) (*server.Server, error) {
+	conf := filepath.Join(dir, "server.conf")
+	err := os.WriteFile(conf, []byte(config), 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := server.ProcessConfigFile(conf)
+	if err != nil {
+		return nil, err
+	}
+	opts.NoSigs = true
+
+	s, err := server.NewServer(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	go s.Start()
+
+	if !s.ReadyForConnections(10 * time.Second) {
+		return nil, fmt.Errorf("nats server did not start")
+	}
+
+	return s, nil
+}
+
+func testMonitoringURL() string {
+	return monitoringURL(testServer)
+}
+
+func monitoringURL(s *server.Server) string {
+	return fmt.Sprintf("http://%s", s.MonitorAddr().String())
+}
+
+// runServer starts an extra server for a single test, for topologies such as
+// clusters that the shared test server does not have.
+func runServer(t *testing.T, config string) *server.Server {
+	t.Helper()
+
+	s, err := startServerConfig(t.TempDir(), config)
+	if err != nil {
+		t.Fatalf("could not start server: %v", err)
+	}
+	t.Cleanup(s.Shutdown)
+
+	return s
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not find a free port: %v", err)
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// waitFor polls check until it succeeds or a few seconds have passed.
+func waitFor(t *testing.T, what string, check func() bool) {
+	t.Helper()
+
+	waitForTimeout(t, what, 5*time.Second, check)
+}
+
+// waitForTimeout is waitFor for checks that need longer, such as cluster
+// elections.
+func waitForTimeout(t *testing.T, what string, timeout time.Duration, check func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for !check() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// queryConfig runs a query against a plugin instance that only has a
+// connection with the given config.
+func queryConfig(t *testing.T, config, table string, columns []string, quals ...*proto.Qual) []testRow {
+	t.Helper()
+
+	connection := fmt.Sprintf("nats_%d", time.Now().UnixNano())
+	p, err := newTestPlugin(map[string]string{connection: config})
+	if err != nil {
+		t.Fatalf("could not create plugin: %v", err)
+	}
+	defer connections.Close(connection)
+
+	return queryPlugin(t, p, connection, table, columns, quals...)
+}
+
+func createFixtures(url string) error {
+	nc, err := nats.Connect(url, nats.UserInfo("app", "app"))
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	manager, err := jsm.New(nc)
+	if err != nil {
+		return err
+	}
+
+	_, err = manager.NewStream("ORDERS", jsm.Subjects("orders.>"), jsm.FileStorage(), jsm.StreamDescription("test orders"))
+	if err != nil {
+		return err
+	}
+
+	_, err = manager.NewConsumer("ORDERS", jsm.DurableName("PROCESSOR"), jsm.FilterStreamBySubject("orders.new"), jsm.AcknowledgeExplicit())
+	if err != nil {
+		return err
+	}
+
+	_, err = manager.NewStream("SHIPMENTS", jsm.Subjects("shipments.>"), jsm.MemoryStorage())
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{"PROCESSOR", "TRACKER"} {
+		_, err = manager.NewConsumer("SHIPMENTS", jsm.DurableName(name))
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := nc.Request("orders.new", []byte(fmt.Sprintf(`{"id":%d}`, i)), time.Second); err != nil {
+			return err
+		}
+	}
+
+	js, err := nc.JetStream()
+	if err != nil {
+		return err
+	}
+
+	kv, err := js.CreateKeyValue(&nats.KeyValueConfig{Bucket: "config", Description: "test config"})
+	if err != nil {
+		return err
+	}
+	if _, err := kv.PutString("region", "eu"); err != nil {
+		return err
+	}
+
+	obs, err := js.CreateObjectStore(&nats.ObjectStoreConfig{Bucket: "models", Description: "test models"})
+	if err != nil {
+		return err
+	}
+	if _, err := obs.PutString("model.bin", "weights"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func newTestPlugin(configs map[string]string) (*plugin.Plugin, error) {
+	p := Plugin(context.Background())
+	p.Initialise()
+
+	var connections []*proto.ConnectionConfig
+	for name, config := range configs {
+		connections = append(connections, &proto.ConnectionConfig{Connection: name, Plugin: p.Name, Config: config})
+	}
+
+	err := p.SetAllConnectionConfigs(connections, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// testRow is a result row keyed by column name.
+type testRow map[string]*proto.Column
+
+// executeStream collects the rows streamed back by Plugin.Execute.
+type executeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	rows []testRow
+}
+
+func (s *executeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *executeStream) Send(r *proto.ExecuteResponse) error {
+	if r.Row != nil {
+		s.rows = append(s.rows, r.Row.Columns)
+	}
+	return nil
+}
+
+// query runs a select against table through the plugin with an equality qual
+// on each of the given string columns.
+func query(t *testing.T, table string, columns []string, quals map[string]string) []testRow {
+	t.Helper()
+
+	var qs []*proto.Qual
+	for column, value := range quals {
+		qs = append(qs, qual(column, "=", stringValue(value)))
+	}
+
+	return queryQuals(t, table, columns, qs...)
+}
+
+// queryQuals runs a select against table through the plugin with arbitrary
+// quals, which is what drives the Get and List key columns.
+func queryQuals(t *testing.T, table string, columns []string, quals ...*proto.Qual) []testRow {
+	t.Helper()
+
+	return queryConnection(t, testConnection, table, columns, quals...)
+}
+
+// queryConnection is queryQuals against the named plugin connection.
+func queryConnection(t *testing.T, connection, table string, columns []string, quals ...*proto.Qual) []testRow {
+	t.Helper()
+
+	return queryPlugin(t, testPlugin, connection, table, columns, quals...)
+}
+
+func queryPlugin(t *testing.T, p *plugin.Plugin, connection, table string, columns []string, quals ...*proto.Qual) []testRow {
+	t.Helper()
+
+	return execute(t, p, connection, table, columns, nil, quals...)
+}
+
+// queryLimit is queryQuals with a limit clause.
+func queryLimit(t *testing.T, table string, columns []string, limit int64, quals ...*proto.Qual) []testRow {
+	t.Helper()
+
+	return execute(t, testPlugin, testConnection, table, columns, &proto.NullableInt{Value: limit}, quals...)
+}
+
+func execute(t *testing.T, p *plugin.Plugin, connection, table string, columns []string, limit *proto.NullableInt, quals ...*proto.Qual) []testRow {
+	t.Helper()
+
+	qc := &proto.QueryContext{
+		Columns: columns,
+		Quals:   map[string]*proto.Quals{},
+	}
+	for _, q := range quals {
+		if qc.Quals[q.FieldName] == nil {
+			qc.Quals[q.FieldName] = &proto.Quals{}
+		}
+		qc.Quals[q.FieldName].Quals = append(qc.Quals[q.FieldName].Quals, q)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stream := &executeStream{ctx: ctx}
+	err := p.Execute(&proto.ExecuteRequest{
+		Table:        table,
+		QueryContext: qc,
+		CallId:       fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano()),
+		ExecuteConnectionData: map[string]*proto.ExecuteConnectionData{
+			connection: {Limit: limit},
+		},
+	}, stream)
+	if err != nil {
+		t.Fatalf("query %s failed: %v", table, err)
+	}
+
+	return stream.rows
+}
+
+func qual(column, operator string, value *proto.QualValue) *proto.Qual {
+	return &proto.Qual{
+		FieldName: column,
+		Operator:  &proto.Qual_StringValue{StringValue: operator},
+		Value:     value,
+	}
+}
+
+func stringValue(s string) *proto.QualValue {
+	return &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: s}}
+}
+
+// listValue is the value of an `in (...)` qual.
+func listValue(values ...string) *proto.QualValue {
+	l := &proto.QualValueList{}
+	for _, v := range values {
+		l.Values = append(l.Values, stringValue(v))
+	}
+	return &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: l}}
+}
+
+func boolValue(b bool) *proto.QualValue {
+	return &proto.QualValue{Value: &proto.QualValue_BoolValue{BoolValue: b}}
+}
+
+func intValue(i int64) *proto.QualValue {
+	return &proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: i}}
+}
+
+func timestampValue(t time.Time) *proto.QualValue {
+	return &proto.QualValue{Value: &proto.QualValue_TimestampValue{TimestampValue: timestamppb.New(t)}}
+}
+
+// findRow returns the first row whose columns match the given column, value
+// pairs.
+func findRow(rows []testRow, match ...string) testRow {
+	for _, r := range rows {
+		found := true
+		for i := 0; i+1 < len(match); i += 2 {
+			if r[match[i]].GetStringValue() != match[i+1] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return r
+		}
+	}
+	return nil
+}
+
+func requireRow(t *testing.T, rows []testRow, match ...string) testRow {
+	t.Helper()
+
+	r := findRow(rows, match...)
+	if r == nil {
+		t.Fatalf("no row matching %q in %d rows", match, len(rows))
+	}
+	return r
+}
+
+func assertString(t *testing.T, r testRow, column, expected string) {
+	t.Helper()
+
+	if v := r[column].GetStringValue(); v != expected {
+		t.Errorf("expected %s to be %q, got %q", column, expected, v)
+	}
+}
+
+func assertInt(t *testing.T, r testRow, column string, expected int64) {
+	t.Helper()
+
+	if v := r[column].GetIntValue(); v != expected {
+		t.Errorf("expected %s to be %d, got %d", column, expected, v)
+	}
+}
+
+func assertBool(t *testing.T, r testRow, column string, expected bool) {
+	t.Helper()
+
+	if v := r[column].GetBoolValue(); v != expected {
+		t.Errorf("expected %s to be %t, got %t", column, expected, v)
+	}
+}
@@ -0,0 +1,76 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nats-io/nats.go"
+)
+
+// jetStreamTimeout is how long to wait for a JetStream API response, or for
+// the next message of an ordered consumer, before giving up on the stream.
+const jetStreamTimeout = 5 * time.Second
+
+// eachMessage reads the messages of stream matching filter with an ordered
+// consumer, which the server removes once the read is over, and calls cb for
+// each of them until it returns false or no messages are pending. opts choose
+// where the consumer starts.
+func eachMessage(ctx context.Context, js nats.JetStreamContext, stream, filter string, opts []nats.SubOpt, cb func(*nats.Msg, *nats.MsgMetadata) bool) error {
+	opts = append([]nats.SubOpt{nats.BindStream(stream), nats.OrderedConsumer()}, opts...)
+
+	sub, err := js.SubscribeSync(filter, opts...)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	consumer, err := sub.ConsumerInfo()
+	if err != nil {
+		return err
+	}
+	// The consumer starts delivering as soon as it is created, so nothing
+	// pending only means there is nothing to read when nothing was delivered.
+	if consumer.NumPending == 0 && consumer.Delivered.Consumer == 0 {
+		return nil
+	}
+
+	for {
+		msgCtx, cancel := context.WithTimeout(ctx, jetStreamTimeout)
+		msg, err := sub.NextMsgWithContext(msgCtx)
+		cancel()
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		meta, err := msg.Metadata()
+		if err != nil {
+			return err
+		}
+
+		if !cb(msg, meta) || meta.NumPending == 0 {
+			return nil
+		}
+	}
+}
+
+// decodePayload returns data as text when it is valid UTF-8 and as JSON when
+// it is valid JSON.
+func decodePayload(data []byte) (string, json.RawMessage) {
+	var text string
+	if utf8.Valid(data) {
+		text = string(data)
+	}
+
+	var value json.RawMessage
+	if json.Valid(data) {
+		value = data
+	}
+
+	return text, value
+}
@@ -0,0 +1,194 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// monitoringRequest is a request for a server monitoring payload such as
+// varz. The HTTP source sends Query as URL parameters, while the system source
+// sends Options as the JSON body of its $SYS request. AnyStatus makes the HTTP
+// source accept JSON error responses too, for endpoints such as healthz that
+// report failures in their payload.
+type monitoringRequest struct {
+	Endpoint  string
+	Query     url.Values
+	Options   interface{}
+	AnyStatus bool
+}
+
+// monitoringSource fetches monitoring payloads from every server it can reach.
+// A failing server is reported in its response rather than failing the others.
+type monitoringSource interface {
+	Fetch(ctx context.Context, req monitoringRequest) ([]serverResponse, error)
+	// FetchServer repeats a request against the single server a previous
+	// response came from, identified by its Target.
+	FetchServer(ctx context.Context, target string, req monitoringRequest) serverResponse
+	close()
+}
+
+// newMonitoringSource creates the source selected by monitoring_source.
+// connect returns the NATS connection used for system account requests.
+func newMonitoringSource(c *natsConfig, connect func() (*nats.Conn, error)) (monitoringSource, error) {
+	switch strings.ToLower(String(c.MonitoringSource)) {
+	case "", "http":
+		return newHTTPMonitor(c)
+	case "system":
+		return newSystemMonitor(c, connect)
+	default:
+		return nil, fmt.Errorf("invalid monitoring_source %q, expected \"http\" or \"system\"", *c.MonitoringSource)
+	}
+}
+
+// monitoringTimeout is the monitoring_timeout setting, or def when unset.
+func (c *natsConfig) monitoringTimeout(def time.Duration) (time.Duration, error) {
+	if String(c.MonitoringTimeout) == "" {
+		return def, nil
+	}
+
+	timeout, err := time.ParseDuration(*c.MonitoringTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid monitoring_timeout: %w", err)
+	}
+
+	return timeout, nil
+}
+
+// serverResponse is the raw monitoring response of a single server. URL is
+// where it came from: the monitoring URL for HTTP, or the server's own $SYS
+// request subject for the system source. Target addresses the same server in
+// FetchServer. ServerID and ServerName are only set by sources that identify
+// the responding server themselves, like the $SYS reply envelope.
+type serverResponse struct {
+	URL        string
+	Target     string
+	ServerID   string
+	ServerName string
+	Data       json.RawMessage
+	Err        error
+}
+
+// serverResult is one server's decoded monitoring response.
+type serverResult[T any] struct {
+	URL        string
+	Target     string
+	ServerID   string
+	ServerName string
+	Value      T
+	Err        error
+}
+
+// decodeResponse decodes a raw server response into a T.
+func decodeResponse[T any](r serverResponse) serverResult[T] {
+	res := serverResult[T]{URL: r.URL, Target: r.Target, ServerID: r.ServerID, ServerName: r.ServerName, Err: r.Err}
+	if res.Err == nil {
+		res.Err = json.Unmarshal(r.Data, &res.Value)
+	}
+	return res
+}
+
+// fetchServers fetches req from every monitored server and decodes each
+// response into a T. It only fails when no server could be queried at all.
+func fetchServers[T any](ctx context.Context, source monitoringSource, req monitoringRequest) ([]serverResult[T], error) {
+	responses, err := source.Fetch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]serverResult[T], 0, len(responses))
+	failed := 0
+	for _, r := range responses {
+		res := decodeResponse[T](r)
+		if res.Err != nil {
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	if failed == len(results) && failed > 0 {
+		return nil, results[0].Err
+	}
+
+	return results, nil
+}
+
+// fetchServer fetches req from the one server addressed by target and decodes
+// its response into a T.
+func fetchServer[T any](ctx context.Context, source monitoringSource, target string, req monitoringRequest) serverResult[T] {
+	return decodeResponse[T](source.FetchServer(ctx, target, req))
+}
+
+// serverDirectory identifies the monitored servers whose varz could be
+// fetched, for payloads that only identify servers by ID or not at all.
+type serverDirectory struct {
+	names map[string]string
+	ids   map[string]string
+}
+
+// lookupServers identifies the servers that returned results. When the source
+// already identified them, as the system source does, that is used as is;
+// otherwise the varz of every monitored server is fetched.
+func lookupServers[T any](ctx context.Context, source monitoringSource, fetched []serverResult[T]) serverDirectory {
+	dir := serverDirectory{names: map[string]string{}, ids: map[string]string{}}
+
+	identified := false
+	for _, r := range fetched {
+		if r.ServerID != "" {
+			dir.names[r.ServerID] = r.ServerName
+			dir.ids[r.Target] = r.ServerID
+			identified = true
+		}
+	}
+	if identified {
+		return dir
+	}
+
+	results, err := fetchServers[server.Varz](ctx, source, monitoringRequest{Endpoint: "varz"})
+	if err != nil {
+		return dir
+	}
+	for _, r := range results {
+		if r.Err == nil {
+			dir.names[r.Value.ID] = r.Value.Name
+			dir.ids[r.Target] = r.Value.ID
+		}
+	}
+
+	return dir
+}
+
+// name is the name of the server with the given ID.
+func (dir serverDirectory) name(id string) string {
+	return dir.names[id]
+}
+
+// id is the ID of the server a response with the given Target came from.
+func (dir serverDirectory) id(target string) string {
+	return dir.ids[target]
+}
+
+// serverKeyColumns are the optional quals matchesServerQuals filters on.
+func serverKeyColumns() plugin.KeyColumnSlice {
+	return plugin.OptionalColumns([]string{"server_name", "server_id"})
+}
+
+// matchesServerQuals reports whether a server passes the server_name and
+// server_id quals of the query. A server that could not be queried has no
+// name or id, so it is only returned when neither qual is set.
+func matchesServerQuals(d *plugin.QueryData, id, name string) bool {
+	if names := qualStrings(d, "server_name"); names != nil && !stringIn(name, names) {
+		return false
+	}
+	if ids := qualStrings(d, "server_id"); ids != nil && !stringIn(id, ids) {
+		return false
+	}
+	return true
+}
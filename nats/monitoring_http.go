@@ -0,0 +1,187 @@
+package nats
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultHTTPMonitoringTimeout = 10 * time.Second
+
+// httpMonitor fetches monitoring payloads from the HTTP monitoring endpoints of
+// the servers listed in monitoring_url and monitoring_urls.
+type httpMonitor struct {
+	client  *http.Client
+	header  http.Header
+	urls    []string
+	workers int
+}
+
+func newHTTPMonitor(c *natsConfig) (*httpMonitor, error) {
+	var urls []string
+	if c.MonitoringURLs != nil {
+		urls = append(urls, *c.MonitoringURLs...)
+	}
+	if String(c.MonitoringURL) != "" {
+		urls = append(urls, *c.MonitoringURL)
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("neither monitoring_url nor monitoring_urls is set")
+	}
+
+	timeout, err := c.monitoringTimeout(defaultHTTPMonitoringTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := c.monitoringTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	if c.MonitoringHeaders != nil {
+		for _, h := range *c.MonitoringHeaders {
+			name, value, ok := strings.Cut(h, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid monitoring_headers entry %q, expected \"Name: value\"", h)
+			}
+			header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+	}
+	if c.MonitoringToken != nil {
+		header.Set("Authorization", "Bearer "+*c.MonitoringToken)
+	} else if c.MonitoringUsername != nil {
+		auth := base64.StdEncoding.EncodeToString([]byte(*c.MonitoringUsername + ":" + String(c.MonitoringPassword)))
+		header.Set("Authorization", "Basic "+auth)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	for i, u := range urls {
+		urls[i] = strings.TrimSuffix(u, "/")
+		if p := strings.Trim(String(c.MonitoringBasePath), "/"); p != "" {
+			urls[i] = fmt.Sprintf("%s/%s", urls[i], p)
+		}
+	}
+
+	return &httpMonitor{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+		},
+		header:  header,
+		urls:    urls,
+		workers: c.maxConcurrency(),
+	}, nil
+}
+
+// monitoringTLSConfig builds the TLS settings for the HTTP monitor. The
+// monitoring_tls* settings take precedence over the NATS client tls* ones.
+func (c *natsConfig) monitoringTLSConfig() (*tls.Config, error) {
+	cert, key, ca := c.TLSCert, c.TLSKey, c.TLSCACert
+	if c.MonitoringTLSCert != nil || c.MonitoringTLSKey != nil {
+		if c.MonitoringTLSCert == nil || c.MonitoringTLSKey == nil {
+			return nil, fmt.Errorf("monitoring_tlscert and monitoring_tlskey must be set together")
+		}
+		cert, key = c.MonitoringTLSCert, c.MonitoringTLSKey
+	}
+	if c.MonitoringTLSCACert != nil {
+		ca = c.MonitoringTLSCACert
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cert != nil && key != nil {
+		pair, err := tls.LoadX509KeyPair(*cert, *key)
+		if err != nil {
+			return nil, fmt.Errorf("could not load monitoring client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	if ca != nil {
+		pem, err := os.ReadFile(*ca)
+		if err != nil {
+			return nil, fmt.Errorf("could not read monitoring CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in monitoring CA %s", *ca)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// Fetch requests the endpoint from every configured server in parallel.
+func (m *httpMonitor) Fetch(ctx context.Context, req monitoringRequest) ([]serverResponse, error) {
+	var responses []serverResponse
+
+	err := fanOut(ctx, m.workers, m.urls, func(base string) ([]serverResponse, error) {
+		return []serverResponse{m.FetchServer(ctx, base, req)}, nil
+	}, func(r serverResponse) bool {
+		responses = append(responses, r)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// FetchServer requests the endpoint from the server at the base URL target.
+func (m *httpMonitor) FetchServer(ctx context.Context, target string, req monitoringRequest) serverResponse {
+	data, err := m.get(ctx, target, req)
+	return serverResponse{URL: target, Target: target, Data: data, Err: err}
+}
+
+func (m *httpMonitor) get(ctx context.Context, base string, r monitoringRequest) (json.RawMessage, error) {
+	u := fmt.Sprintf("%s/%s", base, r.Endpoint)
+	if len(r.Query) > 0 {
+		u = fmt.Sprintf("%s?%s", u, r.Query.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range m.header {
+		req.Header[name] = values
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && !(r.AnyStatus && json.Valid(body)) {
+		if len(body) > 512 {
+			body = body[:512]
+		}
+		return nil, fmt.Errorf("%s returned %s: %s", u, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+func (m *httpMonitor) close() {
+	m.client.CloseIdleConnections()
+}
@@ -0,0 +1,278 @@
+package nats
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPMonitorGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/nats/varz" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("expected bearer token, got %q", got)
+		}
+		if got := r.Header.Get("X-Org"); got != "acme" {
+			t.Errorf("expected custom header, got %q", got)
+		}
+		if got := r.URL.Query().Get("auth"); got != "true" {
+			t.Errorf("expected auth query parameter, got %q", got)
+		}
+		w.Write([]byte(`{"server_name":"n1"}`))
+	}))
+	defer srv.Close()
+
+	m, err := newHTTPMonitor(&natsConfig{
+		MonitoringURL:      strPtr(srv.URL + "/"),
+		MonitoringBasePath: strPtr("/nats/"),
+		MonitoringToken:    strPtr("secret"),
+		MonitoringHeaders:  &[]string{"X-Org: acme"},
+		MonitoringTimeout:  strPtr("2s"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := fetchServers[testVarz](context.Background(), m, monitoringRequest{Endpoint: "varz", Query: url.Values{"auth": []string{"true"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Value.Name != "n1" {
+		t.Errorf("expected server_name n1, got %+v", results)
+	}
+
+	_, err = fetchServers[testVarz](context.Background(), m, monitoringRequest{Endpoint: "missing"})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a 404 error, got %v", err)
+	}
+}
+
+type testVarz struct {
+	Name string `json:"server_name"`
+}
+
+func TestHTTPMonitorMultipleServers(t *testing.T) {
+	var urls []string
+	for _, name := range []string{"n1", "n2"} {
+		name := name
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"server_name":"` + name + `"}`))
+		}))
+		defer srv.Close()
+		urls = append(urls, srv.URL)
+	}
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+
+	m, err := newHTTPMonitor(&natsConfig{
+		MonitoringURLs: &[]string{urls[0], urls[1], broken.URL},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := fetchServers[testVarz](context.Background(), m, monitoringRequest{Endpoint: "varz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	names := map[string]string{}
+	for _, r := range results {
+		if r.URL == broken.URL {
+			if r.Err == nil {
+				t.Errorf("expected an error for %s", r.URL)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Errorf("unexpected error for %s: %v", r.URL, r.Err)
+		}
+		names[r.URL] = r.Value.Name
+	}
+	if names[urls[0]] != "n1" || names[urls[1]] != "n2" {
+		t.Errorf("unexpected results %v", names)
+	}
+}
+
+func TestHTTPMonitorBasicAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "admin" || pass != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	m, err := newHTTPMonitor(&natsConfig{
+		MonitoringURL:      strPtr(srv.URL),
+		MonitoringUsername: strPtr("admin"),
+		MonitoringPassword: strPtr("s3cret"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fetchServers[struct{}](context.Background(), m, monitoringRequest{Endpoint: "varz"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHTTPMonitorConfigErrors(t *testing.T) {
+	for _, c := range []*natsConfig{
+		{},
+		{MonitoringURL: strPtr("http://localhost:8222"), MonitoringTimeout: strPtr("soon")},
+		{MonitoringURL: strPtr("http://localhost:8222"), MonitoringHeaders: &[]string{"no-colon"}},
+		{MonitoringURL: strPtr("http://localhost:8222"), MonitoringTLSCACert: strPtr("/does/not/exist.pem")},
+		{MonitoringURL: strPtr("http://localhost:8222"), MonitoringTLSCert: strPtr("client.pem")},
+		{MonitoringURL: strPtr("http://localhost:8222"), MonitoringTLSKey: strPtr("client-key.pem")},
+	} {
+		if _, err := newHTTPMonitor(c); err == nil {
+			t.Errorf("expected an error for %+v", c)
+		}
+	}
+}
+
+// testCertificate is a certificate signed by a test CA, with its PEM files.
+type testCertificate struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+// issueTestCertificate creates a certificate for 127.0.0.1 signed by ca, or a
+// self-signed CA when ca is nil, and writes it to dir.
+func issueTestCertificate(t *testing.T, dir, name string, ca *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	parent, signer := template, key
+	if ca == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		parent, signer = ca.cert, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &testCertificate{
+		cert:     cert,
+		key:      key,
+		certFile: filepath.Join(dir, name+".pem"),
+		keyFile:  filepath.Join(dir, name+"-key.pem"),
+	}
+	if err := os.WriteFile(c.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(c.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestHTTPMonitorClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := issueTestCertificate(t, dir, "ca", nil)
+	serverCert := issueTestCertificate(t, dir, "server", ca)
+	client := issueTestCertificate(t, dir, "client", ca)
+
+	pair, err := tls.LoadX509KeyPair(serverCert.certFile, serverCert.keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"server_name":"` + r.TLS.PeerCertificates[0].Subject.CommonName + `"}`))
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	m, err := newHTTPMonitor(&natsConfig{
+		MonitoringURL:       strPtr(srv.URL),
+		MonitoringTLSCert:   strPtr(client.certFile),
+		MonitoringTLSKey:    strPtr(client.keyFile),
+		MonitoringTLSCACert: strPtr(ca.certFile),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := fetchServers[testVarz](context.Background(), m, monitoringRequest{Endpoint: "varz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Value.Name != "client" {
+		t.Errorf("expected the client certificate to be presented, got %+v", results)
+	}
+
+	// Without a client certificate the handshake is refused.
+	m, err = newHTTPMonitor(&natsConfig{
+		MonitoringURL:       strPtr(srv.URL),
+		MonitoringTLSCACert: strPtr(ca.certFile),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fetchServers[testVarz](context.Background(), m, monitoringRequest{Endpoint: "varz"}); err == nil {
+		t.Error("expected the request without a client certificate to fail")
+	}
+}
@@ -0,0 +1,169 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+const defaultSystemMonitoringTimeout = 2 * time.Second
+
+// systemMonitor fetches monitoring payloads with $SYS.REQ.SERVER.PING
+// scatter-gather requests, which needs a connection to the system account.
+type systemMonitor struct {
+	connect func() (*nats.Conn, error)
+	timeout time.Duration
+}
+
+// systemResponse is the envelope servers reply to $SYS requests with.
+type systemResponse struct {
+	Server *server.ServerInfo `json:"server"`
+	Data   json.RawMessage    `json:"data"`
+	Error  *server.ApiError   `json:"error"`
+}
+
+func newSystemMonitor(c *natsConfig, connect func() (*nats.Conn, error)) (*systemMonitor, error) {
+	timeout, err := c.monitoringTimeout(defaultSystemMonitoringTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &systemMonitor{
+		connect: connect,
+		timeout: timeout,
+	}, nil
+}
+
+// Fetch publishes a ping request for the endpoint and collects the replies of
+// every server until the monitoring timeout expires.
+func (m *systemMonitor) Fetch(ctx context.Context, req monitoringRequest) ([]serverResponse, error) {
+	nc, err := m.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	inbox := nc.NewRespInbox()
+	sub, err := nc.SubscribeSync(inbox)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	subject := systemSubject(req.Endpoint, "PING")
+	if err := nc.PublishRequest(subject, inbox, body); err != nil {
+		return nil, err
+	}
+
+	collectCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	var responses []serverResponse
+	for {
+		msg, err := sub.NextMsgWithContext(collectCtx)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(msg.Data) == 0 && msg.Header.Get("Status") == "503" {
+			return nil, fmt.Errorf("no servers responded to %s, is the connection using the system account?", subject)
+		}
+
+		r, err := decodeSystemResponse(subject, req.Endpoint, msg.Data)
+		if err != nil {
+			r = serverResponse{URL: subject, Err: err}
+		}
+		responses = append(responses, r)
+	}
+
+	return responses, nil
+}
+
+// FetchServer sends the request to the server whose ID is target only.
+func (m *systemMonitor) FetchServer(ctx context.Context, target string, req monitoringRequest) serverResponse {
+	subject := systemSubject(req.Endpoint, target)
+	failed := serverResponse{URL: subject, Target: target}
+
+	nc, err := m.connect()
+	if err != nil {
+		failed.Err = err
+		return failed
+	}
+
+	body, err := requestBody(req)
+	if err != nil {
+		failed.Err = err
+		return failed
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	msg, err := nc.RequestWithContext(reqCtx, subject, body)
+	if err != nil {
+		failed.Err = fmt.Errorf("%s: %w", subject, err)
+		return failed
+	}
+
+	r, err := decodeSystemResponse(subject, req.Endpoint, msg.Data)
+	if err != nil {
+		failed.Err = err
+		return failed
+	}
+
+	return r
+}
+
+// systemSubject is the $SYS subject serving endpoint on the server with the
+// given ID, or on every server for PING. Account statistics are only served
+// per account or to a ping of all servers, so accstatz always pings.
+func systemSubject(endpoint, id string) string {
+	if endpoint == "accstatz" {
+		return "$SYS.REQ.ACCOUNT.PING.STATZ"
+	}
+	return fmt.Sprintf("$SYS.REQ.SERVER.%s.%s", id, strings.ToUpper(endpoint))
+}
+
+// requestBody is the JSON body of a $SYS request for req.
+func requestBody(req monitoringRequest) ([]byte, error) {
+	if req.Options == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(req.Options)
+}
+
+// decodeSystemResponse unwraps the reply envelope of a single server.
+func decodeSystemResponse(subject, endpoint string, data []byte) (serverResponse, error) {
+	var resp systemResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return serverResponse{}, fmt.Errorf("invalid response to %s: %w", subject, err)
+	}
+
+	r := serverResponse{Data: resp.Data}
+	if resp.Server != nil {
+		r.URL = systemSubject(endpoint, resp.Server.ID)
+		r.Target = resp.Server.ID
+		r.ServerID = resp.Server.ID
+		r.ServerName = resp.Server.Name
+	}
+	if resp.Error != nil {
+		r.Err = resp.Error
+	}
+
+	return r, nil
+}
+
+func (m *systemMonitor) close() {}
@@ -0,0 +1,134 @@
+package nats
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// testConnect dials the test server once as user, whose password is the same
+// as the name, and returns a connect func handing out that connection. The
+// connection is closed when the test ends.
+func testConnect(t *testing.T, user string) func() (*nats.Conn, error) {
+	t.Helper()
+
+	nc, err := nats.Connect(testServer.ClientURL(), nats.UserInfo(user, user))
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	return func() (*nats.Conn, error) {
+		return nc, nil
+	}
+}
+
+func TestSystemMonitorFetch(t *testing.T) {
+	m, err := newSystemMonitor(&natsConfig{MonitoringTimeout: strPtr("250ms")}, testConnect(t, "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := fetchServers[server.Varz](context.Background(), m, monitoringRequest{Endpoint: "varz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 response, got %d", len(results))
+	}
+	if results[0].Value.Name != "test-server" {
+		t.Errorf("expected server_name test-server, got %q", results[0].Value.Name)
+	}
+	if !strings.HasPrefix(results[0].URL, "$SYS.REQ.SERVER."+testServer.ID()) {
+		t.Errorf("unexpected source %q", results[0].URL)
+	}
+}
+
+func TestSystemMonitorRequiresSystemAccount(t *testing.T) {
+	m, err := newSystemMonitor(&natsConfig{MonitoringTimeout: strPtr("250ms")}, testConnect(t, "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.Fetch(context.Background(), monitoringRequest{Endpoint: "varz"})
+	if err == nil {
+		t.Fatal("expected an error when not connected to the system account")
+	}
+}
+
+func TestSystemMonitorInvalidResponse(t *testing.T) {
+	connect := testConnect(t, "sys")
+
+	// Answer the ping next to the server with a reply that cannot be decoded.
+	nc, err := connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nc.Subscribe("$SYS.REQ.SERVER.PING.VARZ", func(msg *nats.Msg) { msg.Respond([]byte("not json")) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := nc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := newSystemMonitor(&natsConfig{MonitoringTimeout: strPtr("250ms")}, connect)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses, err := m.Fetch(context.Background(), monitoringRequest{Endpoint: "varz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+
+	failed := 0
+	for _, r := range responses {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("expected only the invalid response to fail, got %d failures", failed)
+	}
+}
+
+// countingSource counts the fetches made through a monitoring source.
+type countingSource struct {
+	monitoringSource
+	fetches int
+}
+
+func (s *countingSource) Fetch(ctx context.Context, req monitoringRequest) ([]serverResponse, error) {
+	s.fetches++
+	return s.monitoringSource.Fetch(ctx, req)
+}
+
+func TestSystemMonitorIdentifiesServers(t *testing.T) {
+	m, err := newSystemMonitor(&natsConfig{MonitoringTimeout: strPtr("250ms")}, testConnect(t, "sys"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := &countingSource{monitoringSource: m}
+
+	results, err := fetchServers[server.Routez](context.Background(), source, monitoringRequest{Endpoint: "routez"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	servers := lookupServers(context.Background(), source, results)
+	if source.fetches != 1 {
+		t.Errorf("expected the servers to be identified without fetching varz, got %d fetches", source.fetches)
+	}
+	if name := servers.name(testServer.ID()); name != "test-server" {
+		t.Errorf("expected server_name test-server, got %q", name)
+	}
+	if id := servers.id(results[0].Target); id != testServer.ID() {
+		t.Errorf("expected server_id %s, got %q", testServer.ID(), id)
+	}
+}
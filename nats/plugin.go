@@ -15,13 +15,35 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			NewInstance: connConfig,
 			Schema:      configSchema,
 		},
+		ConnectionConfigChangedFunc: connectionConfigChanged,
+		DefaultIgnoreConfig: &plugin.IgnoreConfig{
+			ShouldIgnoreErrorFunc: shouldIgnoreError,
+		},
 		TableMap: map[string]*plugin.Table{
-			"stream_configs":   streamConfigs(),
-			"consumer_configs": consumerConfigs(),
-			"stream_info":      streamInfo(),
-			"consumer_info":    consumerInfo(),
-			"varz_info":        varzInfo(),
-			"kv_info":          kvInfo(),
+			"stream_configs":         streamConfigs(),
+			"consumer_configs":       consumerConfigs(),
+			"stream_info":            streamInfo(),
+			"stream_messages":        streamMessages(),
+			"stream_subjects":        streamSubjects(),
+			"stream_replicas":        streamReplicas(),
+			"consumer_info":          consumerInfo(),
+			"varz_info":              varzInfo(),
+			"connz_info":             connzInfo(),
+			"routez_info":            routezInfo(),
+			"gatewayz_info":          gatewayzInfo(),
+			"gatewayz_accounts":      gatewayzAccounts(),
+			"leafz_info":             leafzInfo(),
+			"subsz_info":             subszInfo(),
+			"accountz_info":          accountzInfo(),
+			"accstatz_info":          accstatzInfo(),
+			"jsz_info":               jszInfo(),
+			"healthz":                healthzInfo(),
+			"jetstream_account_info": jetStreamAccountInfo(),
+			"kv_info":                kvInfo(),
+			"kv_entries":             kvEntries(),
+			"kv_history":             kvHistory(),
+			"object_store_buckets":   objectStoreBuckets(),
+			"object_store_objects":   objectStoreObjects(),
 		},
 	}
 	return p
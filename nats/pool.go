@@ -0,0 +1,76 @@
+package nats
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultMaxConcurrency is the number of parallel JetStream API requests made
+// by a single hydrate call when max_concurrency is not configured.
+const defaultMaxConcurrency = 10
+
+// fanOut calls fn for every item using at most workers goroutines. Results are
+// handed to emit on the calling goroutine as soon as they arrive, so emit may
+// stream rows; it returns false once no more results are wanted. Cancelling
+// ctx, an error from fn, or emit returning false stops outstanding workers.
+func fanOut[T, R any](ctx context.Context, workers int, items []T, fn func(T) ([]R, error), emit func(R) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		values []R
+		err    error
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	work := make(chan T)
+	results := make(chan result)
+
+	go func() {
+		defer close(work)
+		for _, item := range items {
+			select {
+			case work <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				values, err := fn(item)
+				select {
+				case results <- result{values: values, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for r := range results {
+		if r.err != nil {
+			return r.err
+		}
+		for _, v := range r.values {
+			if !emit(v) {
+				return nil
+			}
+		}
+	}
+
+	return ctx.Err()
+}
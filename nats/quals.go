@@ -0,0 +1,138 @@
+package nats
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// qualStrings returns the values of an equality qual on column, expanding
+// `in` lists. It returns nil when the query has no such qual.
+func qualStrings(d *plugin.QueryData, column string) []string {
+	q, ok := d.KeyColumnQuals[column]
+	if !ok {
+		return nil
+	}
+
+	if l := q.GetListValue(); l != nil {
+		var values []string
+		for _, v := range l.Values {
+			values = append(values, v.GetStringValue())
+		}
+		return values
+	}
+
+	return []string{q.GetStringValue()}
+}
+
+// narrowSubject returns the narrowest subject the server can filter on for the
+// quals on exactColumn and filterColumn, or an empty string when neither is
+// set. Postgres compares exactColumn to its qual exactly, so wildcards are only
+// taken from filterColumn. ok is false when the exact subject is outside the
+// filter and nothing can match.
+func narrowSubject(d *plugin.QueryData, exactColumn, filterColumn string) (subject string, ok bool) {
+	exact := d.KeyColumnQuals[exactColumn].GetStringValue()
+	filter := d.KeyColumnQuals[filterColumn].GetStringValue()
+
+	if exact == "" {
+		return filter, true
+	}
+	if filter != "" && !subjectMatches(filter, exact) {
+		return "", false
+	}
+
+	return exact, true
+}
+
+// rangeOperators are the operators seqRange and timeRange understand.
+var rangeOperators = []string{"=", ">", ">=", "<", "<="}
+
+// seqRange returns the inclusive bounds the quals on column put on a
+// sequence. max is math.MaxUint64 when there is no upper bound, and ok is
+// false when no sequence can match, as sequences start at 1.
+func seqRange(d *plugin.QueryData, column string) (min, max uint64, ok bool) {
+	max = math.MaxUint64
+
+	q, found := d.Quals[column]
+	if !found {
+		return min, max, true
+	}
+
+	for _, qual := range q.Quals {
+		v := qual.Value.GetInt64Value()
+		if v < 0 {
+			v = 0
+		}
+		seq := uint64(v)
+
+		switch qual.Operator {
+		case "=":
+			min, max = seq, seq
+		case ">":
+			min = seq + 1
+		case ">=":
+			min = seq
+		case "<":
+			if seq == 0 {
+				return 0, 0, false
+			}
+			max = seq - 1
+		case "<=":
+			max = seq
+		}
+	}
+
+	return min, max, max > 0 && min <= max
+}
+
+// timeRange returns the bounds the quals on column put on a timestamp. The
+// bounds are inclusive, and zero when unbounded. Postgres applies the exact
+// comparison to the returned rows.
+func timeRange(d *plugin.QueryData, column string) (from, to time.Time) {
+	q, ok := d.Quals[column]
+	if !ok {
+		return from, to
+	}
+
+	for _, qual := range q.Quals {
+		ts := qual.Value.GetTimestampValue()
+		if ts == nil {
+			continue
+		}
+		t := ts.AsTime()
+
+		switch qual.Operator {
+		case "=":
+			from, to = t, t
+		case ">", ">=":
+			from = t
+		case "<", "<=":
+			to = t
+		}
+	}
+
+	return from, to
+}
+
+// subjectMatches reports whether subject matches filter, which may contain
+// the * and > wildcards.
+func subjectMatches(filter, subject string) bool {
+	filterTokens := strings.Split(filter, ".")
+	subjectTokens := strings.Split(subject, ".")
+
+	for i, token := range filterTokens {
+		if token == ">" {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) {
+			return false
+		}
+		if token != "*" && token != subjectTokens[i] {
+			return false
+		}
+	}
+
+	return len(filterTokens) == len(subjectTokens)
+}
@@ -0,0 +1,152 @@
+package nats
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func accountzInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "accountz_info",
+		Description: "The accounts of every monitored server",
+		List: &plugin.ListConfig{
+			KeyColumns: append(serverKeyColumns(), plugin.OptionalColumns([]string{"account"})...),
+			Hydrate:    listAccountzInfos,
+		},
+		Columns: []*plugin.Column{
+			{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerID")},
+			{Name: "server_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerName")},
+			{Name: "account", Type: proto.ColumnType_STRING, Transform: transform.FromField("Account")},
+			{Name: "update_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("LastUpdate")},
+			{Name: "is_system", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IsSystem")},
+			{Name: "expired", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Expired")},
+			{Name: "expires", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Expires")},
+			{Name: "complete", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Complete")},
+			{Name: "jetstream_enabled", Type: proto.ColumnType_BOOL, Transform: transform.FromField("JetStream")},
+			{Name: "leafnode_connections", Type: proto.ColumnType_INT, Transform: transform.FromField("LeafCnt")},
+			{Name: "client_connections", Type: proto.ColumnType_INT, Transform: transform.FromField("ClientCnt")},
+			{Name: "subscriptions", Type: proto.ColumnType_INT, Transform: transform.FromField("SubCnt")},
+			{Name: "issuer_key", Type: proto.ColumnType_STRING, Transform: transform.FromField("IssuerKey")},
+			{Name: "name_tag", Type: proto.ColumnType_STRING, Transform: transform.FromField("NameTag")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Transform: transform.FromField("Tags")},
+			{Name: "jwt", Type: proto.ColumnType_STRING, Transform: transform.FromField("Jwt")},
+			{Name: "mappings", Type: proto.ColumnType_JSON, Transform: transform.FromField("Mappings")},
+			{Name: "imports", Type: proto.ColumnType_JSON, Transform: transform.FromField("Imports")},
+			{Name: "exports", Type: proto.ColumnType_JSON, Transform: transform.FromField("Exports")},
+			{Name: "revoked_users", Type: proto.ColumnType_JSON, Transform: transform.FromField("RevokedUser")},
+			{Name: "jwt_validation_issues", Type: proto.ColumnType_JSON, Transform: transform.FromField("Vr")},
+			{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		},
+	}
+}
+
+// AccountzInfo is the account detail of a single account on a server, or the
+// error fetching it. Expires is taken from the account JWT.
+type AccountzInfo struct {
+	server.AccountInfo
+	Account    string     `json:"account"`
+	Expires    *time.Time `json:"expires"`
+	ServerID   string     `json:"server_id"`
+	ServerName string     `json:"server_name"`
+	SourceURL  string     `json:"source_url"`
+	Error      string     `json:"error"`
+}
+
+// accountzTarget is an account of a monitored server.
+type accountzTarget struct {
+	result  serverResult[server.Accountz]
+	account string
+}
+
+func listAccountzInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	monitor, err := getMonitor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := GetConfig(d.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	// Accountz lists account names unless it is asked about one account, so
+	// every account is fetched separately.
+	results, err := fetchServers[server.Accountz](ctx, monitor, accountzRequest(""))
+	if err != nil {
+		return nil, err
+	}
+
+	servers := lookupServers(ctx, monitor, results)
+	accounts := qualStrings(d, "account")
+
+	var targets []accountzTarget
+	for _, r := range results {
+		if !matchesServerQuals(d, r.Value.ID, servers.name(r.Value.ID)) {
+			continue
+		}
+
+		if r.Err != nil {
+			d.StreamListItem(ctx, AccountzInfo{SourceURL: r.URL, Error: errorString(r.Err)})
+			continue
+		}
+
+		for _, account := range r.Value.Accounts {
+			if accounts == nil || stringIn(account, accounts) {
+				targets = append(targets, accountzTarget{result: r, account: account})
+			}
+		}
+	}
+
+	load := func(t accountzTarget) ([]AccountzInfo, error) {
+		info := AccountzInfo{
+			Account:    t.account,
+			ServerID:   t.result.Value.ID,
+			ServerName: servers.name(t.result.Value.ID),
+		}
+
+		r := fetchServer[server.Accountz](ctx, monitor, t.result.Target, accountzRequest(t.account))
+		info.SourceURL = r.URL
+		switch {
+		case r.Err != nil:
+			info.Error = errorString(r.Err)
+		case r.Value.Account != nil:
+			info.AccountInfo = *r.Value.Account
+			if c := r.Value.Account.Claim; c != nil && c.Expires > 0 {
+				expires := time.Unix(c.Expires, 0).UTC()
+				info.Expires = &expires
+			}
+		}
+
+		return []AccountzInfo{info}, nil
+	}
+
+	err = fanOut(ctx, config.maxConcurrency(), targets, load, func(info AccountzInfo) bool {
+		d.StreamListItem(ctx, info)
+		return d.QueryStatus.RowsRemaining(ctx) > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func accountzRequest(account string) monitoringRequest {
+	query := url.Values{}
+	if account != "" {
+		query.Set("acc", account)
+	}
+
+	return monitoringRequest{
+		Endpoint: "accountz",
+		Query:    query,
+		Options:  server.AccountzEventOptions{AccountzOptions: server.AccountzOptions{Account: account}},
+	}
+}
@@ -0,0 +1,47 @@
+package nats
+
+import "testing"
+
+var accountzTestColumns = []string{"server_id", "server_name", "account", "is_system", "jetstream_enabled", "client_connections", "source_url", "error"}
+
+func TestAccountzInfoList(t *testing.T) {
+	rows := query(t, "accountz_info", accountzTestColumns, nil)
+
+	r := requireRow(t, rows, "account", "APP")
+	assertString(t, r, "server_id", testServer.ID())
+	assertString(t, r, "server_name", "test-server")
+	assertBool(t, r, "jetstream_enabled", true)
+	assertBool(t, r, "is_system", false)
+	assertString(t, r, "source_url", testMonitoringURL())
+	assertString(t, r, "error", "")
+
+	r = requireRow(t, rows, "account", "SYS")
+	assertBool(t, r, "is_system", true)
+}
+
+func TestAccountzInfoFilter(t *testing.T) {
+	connectClients(t, "accountz-filter", 1)
+
+	rows := query(t, "accountz_info", accountzTestColumns, map[string]string{"account": "APP"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	if rows[0]["client_connections"].GetIntValue() < 1 {
+		t.Errorf("expected client connections to be counted")
+	}
+
+	rows = query(t, "accountz_info", accountzTestColumns, map[string]string{"account": "MISSING"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
+
+func TestAccountzInfoSystemSource(t *testing.T) {
+	rows := queryConnection(t, testSystemConnection, "accountz_info", accountzTestColumns, qual("account", "=", stringValue("APP")))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertBool(t, rows[0], "jetstream_enabled", true)
+}
@@ -0,0 +1,108 @@
+package nats
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func accstatzInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "accstatz_info",
+		Description: "The per account connection and traffic statistics of every monitored server",
+		List: &plugin.ListConfig{
+			KeyColumns: append(serverKeyColumns(), plugin.OptionalColumns([]string{"account", "include_unused"})...),
+			Hydrate:    listAccstatzInfos,
+		},
+		Columns: []*plugin.Column{
+			{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerID")},
+			{Name: "server_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerName")},
+			{Name: "account", Type: proto.ColumnType_STRING, Transform: transform.FromField("Account")},
+			{Name: "connections", Type: proto.ColumnType_INT, Transform: transform.FromField("Conns")},
+			{Name: "leafnodes", Type: proto.ColumnType_INT, Transform: transform.FromField("LeafNodes")},
+			{Name: "total_connections", Type: proto.ColumnType_INT, Transform: transform.FromField("TotalConns")},
+			{Name: "sent_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("Sent.Msgs")},
+			{Name: "sent_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Sent.Bytes")},
+			{Name: "received_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("Received.Msgs")},
+			{Name: "received_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Received.Bytes")},
+			{Name: "slow_consumers", Type: proto.ColumnType_INT, Transform: transform.FromField("SlowConsumers")},
+			{Name: "include_unused", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IncludeUnused")},
+			{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		},
+	}
+}
+
+// AccstatzInfo is the statistics of a single account on a server, or the
+// error fetching the account statistics of a server. Accounts without
+// connections are only listed when the query sets include_unused.
+type AccstatzInfo struct {
+	server.AccountStat
+	ServerID      string `json:"server_id"`
+	ServerName    string `json:"server_name"`
+	IncludeUnused bool   `json:"include_unused"`
+	SourceURL     string `json:"source_url"`
+	Error         string `json:"error"`
+}
+
+func listAccstatzInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	monitor, err := getMonitor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the system source filters by account, so the account qual is also
+	// applied here.
+	opts := server.AccountStatzOptions{Accounts: qualStrings(d, "account")}
+	if q := d.KeyColumnQuals["include_unused"]; q != nil {
+		opts.IncludeUnused = q.GetBoolValue()
+	}
+
+	query := url.Values{}
+	if opts.IncludeUnused {
+		query.Set("unused", "true")
+	}
+
+	results, err := fetchServers[server.AccountStatz](ctx, monitor, monitoringRequest{
+		Endpoint: "accstatz",
+		Query:    query,
+		Options:  server.AccountStatzEventOptions{AccountStatzOptions: opts},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	servers := lookupServers(ctx, monitor, results)
+
+	for _, r := range results {
+		name := servers.name(r.Value.ID)
+		if !matchesServerQuals(d, r.Value.ID, name) {
+			continue
+		}
+
+		if r.Err != nil {
+			d.StreamListItem(ctx, AccstatzInfo{SourceURL: r.URL, Error: errorString(r.Err)})
+			continue
+		}
+
+		for _, stat := range r.Value.Accounts {
+			if opts.Accounts != nil && !stringIn(stat.Account, opts.Accounts) {
+				continue
+			}
+
+			d.StreamListItem(ctx, AccstatzInfo{
+				AccountStat:   *stat,
+				ServerID:      r.Value.ID,
+				ServerName:    name,
+				IncludeUnused: opts.IncludeUnused,
+				SourceURL:     r.URL,
+			})
+		}
+	}
+
+	return nil, nil
+}
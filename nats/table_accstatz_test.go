@@ -0,0 +1,46 @@
+package nats
+
+import "testing"
+
+var accstatzTestColumns = []string{"server_id", "server_name", "account", "connections", "total_connections", "include_unused", "source_url", "error"}
+
+func TestAccstatzInfoList(t *testing.T) {
+	connectClients(t, "accstatz-list", 1)
+
+	rows := query(t, "accstatz_info", accstatzTestColumns, nil)
+
+	r := requireRow(t, rows, "account", "APP")
+	assertString(t, r, "server_id", testServer.ID())
+	assertString(t, r, "server_name", "test-server")
+	assertString(t, r, "source_url", testMonitoringURL())
+	assertString(t, r, "error", "")
+	if r["connections"].GetIntValue() < 1 {
+		t.Errorf("expected connections to be counted")
+	}
+}
+
+func TestAccstatzInfoFilter(t *testing.T) {
+	connectClients(t, "accstatz-filter", 1)
+
+	rows := query(t, "accstatz_info", accstatzTestColumns, map[string]string{"account": "APP"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertString(t, rows[0], "account", "APP")
+
+	rows = queryQuals(t, "accstatz_info", accstatzTestColumns, qual("include_unused", "=", boolValue(true)))
+	r := requireRow(t, rows, "account", "$G")
+	assertBool(t, r, "include_unused", true)
+}
+
+func TestAccstatzInfoSystemSource(t *testing.T) {
+	connectClients(t, "accstatz-system", 1)
+
+	rows := queryConnection(t, testSystemConnection, "accstatz_info", accstatzTestColumns, qual("account", "=", stringValue("APP")))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "account", "APP")
+}
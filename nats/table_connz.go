@@ -0,0 +1,195 @@
+package nats
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// connzPageSize is the number of connections requested from a server at once.
+var connzPageSize = server.DefaultConnListSize
+
+func connzInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "connz_info",
+		Description: "The client connections of every monitored server",
+		List: &plugin.ListConfig{
+			KeyColumns: plugin.OptionalColumns([]string{"server_id", "cid", "state", "account", "user", "name"}),
+			Hydrate:    listConnzInfos,
+		},
+		Columns: []*plugin.Column{
+			{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerID")},
+			{Name: "cid", Type: proto.ColumnType_INT, Transform: transform.FromField("Cid")},
+			{Name: "state", Type: proto.ColumnType_STRING, Transform: transform.FromField("State")},
+			{Name: "kind", Type: proto.ColumnType_STRING, Transform: transform.FromField("Kind")},
+			{Name: "type", Type: proto.ColumnType_STRING, Transform: transform.FromField("Type")},
+			{Name: "ip", Type: proto.ColumnType_STRING, Transform: transform.FromField("IP")},
+			{Name: "port", Type: proto.ColumnType_INT, Transform: transform.FromField("Port")},
+			{Name: "name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name")},
+			{Name: "lang", Type: proto.ColumnType_STRING, Transform: transform.FromField("Lang")},
+			{Name: "version", Type: proto.ColumnType_STRING, Transform: transform.FromField("Version")},
+			{Name: "account", Type: proto.ColumnType_STRING, Transform: transform.FromField("Account")},
+			{Name: "user", Type: proto.ColumnType_STRING, Transform: transform.FromField("AuthorizedUser")},
+			{Name: "start", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Start")},
+			{Name: "last_activity", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("LastActivity")},
+			{Name: "stop", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Stop")},
+			{Name: "reason", Type: proto.ColumnType_STRING, Transform: transform.FromField("Reason")},
+			{Name: "rtt", Type: proto.ColumnType_STRING, Transform: transform.FromField("RTT")},
+			{Name: "uptime", Type: proto.ColumnType_STRING, Transform: transform.FromField("Uptime")},
+			{Name: "idle", Type: proto.ColumnType_STRING, Transform: transform.FromField("Idle")},
+			{Name: "pending_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Pending")},
+			{Name: "in_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("InMsgs")},
+			{Name: "out_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("OutMsgs")},
+			{Name: "in_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("InBytes")},
+			{Name: "out_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("OutBytes")},
+			{Name: "subscriptions", Type: proto.ColumnType_INT, Transform: transform.FromField("NumSubs")},
+			{Name: "tls_version", Type: proto.ColumnType_STRING, Transform: transform.FromField("TLSVersion")},
+			{Name: "tls_cipher_suite", Type: proto.ColumnType_STRING, Transform: transform.FromField("TLSCipher")},
+			{Name: "tls_peer_certs", Type: proto.ColumnType_JSON, Transform: transform.FromField("TLSPeerCerts")},
+			{Name: "issuer_key", Type: proto.ColumnType_STRING, Transform: transform.FromField("IssuerKey")},
+			{Name: "name_tag", Type: proto.ColumnType_STRING, Transform: transform.FromField("NameTag")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Transform: transform.FromField("Tags")},
+			{Name: "mqtt_client", Type: proto.ColumnType_STRING, Transform: transform.FromField("MQTTClient")},
+			{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		},
+	}
+}
+
+// ConnzInfo is a single client connection of a server, or the error fetching
+// the connections of a server.
+type ConnzInfo struct {
+	server.ConnInfo
+	ServerID  string `json:"server_id"`
+	State     string `json:"state"`
+	SourceURL string `json:"source_url"`
+	Error     string `json:"error"`
+}
+
+func listConnzInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	monitor, err := getMonitor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := connzOptions(d)
+
+	results, err := fetchServers[server.Connz](ctx, monitor, connzRequest(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	names := qualStrings(d, "name")
+
+	for _, r := range results {
+		if r.Err != nil {
+			if matchesServerQuals(d, "", "") {
+				d.StreamListItem(ctx, ConnzInfo{SourceURL: r.URL, Error: errorString(r.Err)})
+			}
+			continue
+		}
+		if !matchesServerQuals(d, r.Value.ID, "") {
+			continue
+		}
+
+		connz := r.Value
+		for {
+			for _, c := range connz.Conns {
+				if names != nil && !stringIn(c.Name, names) {
+					continue
+				}
+
+				d.StreamListItem(ctx, ConnzInfo{
+					ConnInfo:  *c,
+					ServerID:  connz.ID,
+					State:     connState(c),
+					SourceURL: r.URL,
+				})
+
+				if d.QueryStatus.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+
+			// Total counts every matching connection, so keep paging until the
+			// server has nothing past the current page.
+			opts.Offset = connz.Offset + len(connz.Conns)
+			if len(connz.Conns) == 0 || opts.Offset >= connz.Total {
+				break
+			}
+
+			page := fetchServer[server.Connz](ctx, monitor, r.Target, connzRequest(opts))
+			if page.Err != nil {
+				d.StreamListItem(ctx, ConnzInfo{ServerID: connz.ID, SourceURL: page.URL, Error: errorString(page.Err)})
+				break
+			}
+			connz = page.Value
+		}
+	}
+
+	return nil, nil
+}
+
+// connzOptions pushes the cid, state, account and user quals down to the
+// server. Like the connz endpoint itself, only open connections are listed
+// unless the query asks for state = 'closed'. There is no server side filter
+// on the connection name.
+func connzOptions(d *plugin.QueryData) server.ConnzOptions {
+	opts := server.ConnzOptions{
+		Username: true,
+		Limit:    connzPageSize,
+	}
+
+	if q := d.KeyColumnQuals["cid"]; q != nil {
+		opts.CID = uint64(q.GetInt64Value())
+	}
+	if q := d.KeyColumnQuals["state"]; q != nil && q.GetStringValue() == "closed" {
+		opts.State = server.ConnClosed
+	}
+	if q := d.KeyColumnQuals["account"]; q != nil {
+		opts.Account = q.GetStringValue()
+	}
+	if q := d.KeyColumnQuals["user"]; q != nil {
+		opts.User = q.GetStringValue()
+	}
+
+	return opts
+}
+
+// connzRequest builds a connz request for both monitoring sources.
+func connzRequest(opts server.ConnzOptions) monitoringRequest {
+	query := url.Values{}
+	query.Set("auth", "true")
+	query.Set("offset", strconv.Itoa(opts.Offset))
+	query.Set("limit", strconv.Itoa(opts.Limit))
+	if opts.CID != 0 {
+		query.Set("cid", strconv.FormatUint(opts.CID, 10))
+	}
+	if opts.State == server.ConnClosed {
+		query.Set("state", "closed")
+	}
+	if opts.Account != "" {
+		query.Set("acc", opts.Account)
+	}
+	if opts.User != "" {
+		query.Set("user", opts.User)
+	}
+
+	return monitoringRequest{
+		Endpoint: "connz",
+		Query:    query,
+		Options:  server.ConnzEventOptions{ConnzOptions: opts},
+	}
+}
+
+func connState(c *server.ConnInfo) string {
+	if c.Stop != nil {
+		return "closed"
+	}
+	return "open"
+}
@@ -0,0 +1,101 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+var connzTestColumns = []string{"server_id", "cid", "state", "name", "lang", "account", "user", "subscriptions", "source_url", "error"}
+
+// connectClients opens n named APP connections for the duration of the test.
+func connectClients(t *testing.T, name string, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		nc, err := nats.Connect(testServer.ClientURL(), nats.UserInfo("app", "app"), nats.Name(name))
+		if err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+		if _, err := nc.SubscribeSync("connz.test"); err != nil {
+			t.Fatalf("subscribe failed: %v", err)
+		}
+		if err := nc.Flush(); err != nil {
+			t.Fatalf("flush failed: %v", err)
+		}
+		t.Cleanup(nc.Close)
+	}
+}
+
+func TestConnzInfoList(t *testing.T) {
+	connectClients(t, "connz-list", 1)
+
+	rows := query(t, "connz_info", connzTestColumns, map[string]string{"name": "connz-list"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "state", "open")
+	assertString(t, rows[0], "lang", "go")
+	assertString(t, rows[0], "account", "APP")
+	assertString(t, rows[0], "user", "app")
+	assertInt(t, rows[0], "subscriptions", 1)
+	assertString(t, rows[0], "source_url", testMonitoringURL())
+	assertString(t, rows[0], "error", "")
+}
+
+func TestConnzInfoFilter(t *testing.T) {
+	connectClients(t, "connz-filter", 1)
+
+	rows := query(t, "connz_info", connzTestColumns, map[string]string{"name": "connz-filter"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	cid := rows[0]["cid"].GetIntValue()
+
+	rows = queryQuals(t, "connz_info", connzTestColumns, qual("cid", "=", intValue(cid)))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertString(t, rows[0], "name", "connz-filter")
+
+	rows = query(t, "connz_info", connzTestColumns, map[string]string{"name": "connz-filter", "account": "SYS"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+
+	rows = query(t, "connz_info", connzTestColumns, map[string]string{"name": "connz-filter", "user": "app"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+}
+
+func TestConnzInfoPaging(t *testing.T) {
+	pageSize := connzPageSize
+	connzPageSize = 2
+	t.Cleanup(func() { connzPageSize = pageSize })
+
+	connectClients(t, "connz-paging", 5)
+
+	rows := query(t, "connz_info", connzTestColumns, map[string]string{"name": "connz-paging"})
+	if len(rows) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(rows))
+	}
+}
+
+func TestConnzInfoSystemSource(t *testing.T) {
+	pageSize := connzPageSize
+	connzPageSize = 2
+	t.Cleanup(func() { connzPageSize = pageSize })
+
+	connectClients(t, "connz-system", 3)
+
+	rows := queryConnection(t, testSystemConnection, "connz_info", connzTestColumns, qual("name", "=", stringValue("connz-system")))
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "account", "APP")
+}
@@ -15,110 +15,86 @@ func consumerConfigs() *plugin.Table {
 		Name:        "consumer_configs",
 		Description: "The consumer configurations",
 		List: &plugin.ListConfig{
-			Hydrate: listConsumerConfigs,
+			KeyColumns: consumerListKeyColumns(),
+			Hydrate:    listConsumerConfigs,
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AllColumns([]string{"name", "stream"}),
 			Hydrate:    getConsumerConfig,
 		},
-		Columns: []*plugin.Column{
+		Columns: append([]*plugin.Column{
 			{Name: "stream", Type: proto.ColumnType_STRING, Transform: transform.FromField("Stream")},
-			{Name: "name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.Name")},
-			{Name: "filter_subject", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.FilterSubject")},
-			{Name: "description", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.Description")},
-			{Name: "ack_policy", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.AckPolicy")},
-			{Name: "ack_wait", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.AckWait")},
-			{Name: "deliver_policy", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.DeliverPolicy")},
-			{Name: "deliver_subject", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.DeliverSubject")},
-			{Name: "deliver_group", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.DeliverGroup")},
-			{Name: "durable", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.Durable")},
-			{Name: "flow_control", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.FlowControl")},
-			{Name: "heart_beat", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.Heartbeat")},
-			{Name: "max_ack_pending", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxAckPending")},
-			{Name: "max_deliver", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxDeliver")},
-			{Name: "backoff", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Config.Backoff")},
-			{Name: "max_waiting", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxWaiting")},
-			{Name: "opt_start_seq", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.OptStartSeq")},
-			{Name: "opt_start_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Config.OptStartTime")},
-			{Name: "rate_limit", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.RateLimit")},
-			{Name: "replay_policy", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.ReplayPolicy")},
-			{Name: "sample_frequency", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.SampleFrequency")},
-			{Name: "headers_only", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.HeadersOnly")},
-			{Name: "max_batch", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxRequestBatch")},
-			{Name: "max_expires", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxRequestExpires")},
-			{Name: "max_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxRequestMaxBytes")},
-			{Name: "inactive_threshold", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.InactiveThreshold")},
-			{Name: "replicas", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.Replicas")},
-			{Name: "mem_storage", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.MemoryStorage")},
-			{Name: "direct", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.Direct")},
-		},
+			{Name: "name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name")},
+		}, consumerConfigColumns()...),
+	}
+}
+
+// consumerConfigColumns are the columns for a consumer configuration held in a Config field.
+func consumerConfigColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{Name: "filter_subject", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.FilterSubject")},
+		{Name: "description", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.Description")},
+		{Name: "ack_policy", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.AckPolicy")},
+		{Name: "ack_wait", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.AckWait")},
+		{Name: "deliver_policy", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.DeliverPolicy")},
+		{Name: "deliver_subject", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.DeliverSubject")},
+		{Name: "deliver_group", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.DeliverGroup")},
+		{Name: "durable", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.Durable")},
+		{Name: "flow_control", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.FlowControl")},
+		{Name: "heart_beat", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.Heartbeat")},
+		{Name: "max_ack_pending", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxAckPending")},
+		{Name: "max_deliver", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxDeliver")},
+		{Name: "backoff", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Config.Backoff")},
+		{Name: "max_waiting", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxWaiting")},
+		{Name: "opt_start_seq", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.OptStartSeq")},
+		{Name: "opt_start_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Config.OptStartTime")},
+		{Name: "rate_limit", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.RateLimit")},
+		{Name: "replay_policy", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.ReplayPolicy")},
+		{Name: "sample_frequency", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.SampleFrequency")},
+		{Name: "headers_only", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.HeadersOnly")},
+		{Name: "max_batch", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxRequestBatch")},
+		{Name: "max_expires", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxRequestExpires")},
+		{Name: "max_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxRequestMaxBytes")},
+		{Name: "inactive_threshold", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.InactiveThreshold")},
+		{Name: "replicas", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.Replicas")},
+		{Name: "mem_storage", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.MemoryStorage")},
+		{Name: "direct", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.Direct")},
 	}
 }
 
 type ConsumerConfig struct {
 	Stream string `json:"stream"`
+	Name   string `json:"name"`
 	Config *api.ConsumerConfig
 }
 
 func listConsumerConfigs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
-	if err != nil {
-		return nil, err
-	}
-
-	nc, err := config.Connect()
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
 
-	manager, err := jsm.New(nc)
-	if err != nil {
-		return nil, err
-	}
-
-	var streams []string
+	err = eachConsumer(ctx, d, manager, func(v *jsm.Consumer) error {
+		cfg := v.Configuration()
+		c := ConsumerConfig{
+			Stream: v.StreamName(),
+			Name:   v.Name(),
+			Config: &cfg,
+		}
 
-	err = manager.EachStream(func(s *jsm.Stream) {
-		streams = append(streams, s.Configuration().Name)
+		d.StreamListItem(ctx, c)
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, s := range streams {
-		consumers, err := manager.Consumers(s)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, v := range consumers {
-			cfg := v.Configuration()
-			c := ConsumerConfig{
-				Stream: s,
-				Config: &cfg,
-			}
-
-			d.StreamListItem(ctx, c)
-		}
-
-	}
-
 	return nil, nil
 
 }
 
 func getConsumerConfig(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
-	if err != nil {
-		return nil, err
-	}
-
-	nc, err := config.Connect()
-	if err != nil {
-		return nil, err
-	}
-
-	manager, err := jsm.New(nc)
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
@@ -128,13 +104,14 @@ func getConsumerConfig(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 
 	consumer, err := manager.LoadConsumer(stream, name)
 	if err != nil {
-		return nil, err
+		return nil, consumerError(stream, name, err)
 	}
 
 	cfg := consumer.Configuration()
 
 	c := ConsumerConfig{
 		Stream: stream,
+		Name:   consumer.Name(),
 		Config: &cfg,
 	}
 
@@ -0,0 +1,37 @@
+package nats
+
+import "testing"
+
+var consumerConfigTestColumns = []string{"stream", "name", "durable", "filter_subject", "ack_policy"}
+
+func TestConsumerConfigsList(t *testing.T) {
+	rows := query(t, "consumer_configs", consumerConfigTestColumns, nil)
+
+	r := requireRow(t, rows, "stream", "ORDERS", "name", "PROCESSOR")
+	assertString(t, r, "durable", "PROCESSOR")
+	assertString(t, r, "filter_subject", "orders.new")
+	assertString(t, r, "ack_policy", "Explicit")
+}
+
+func TestConsumerConfigsGet(t *testing.T) {
+	rows := query(t, "consumer_configs", consumerConfigTestColumns, map[string]string{"stream": "ORDERS", "name": "PROCESSOR"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "stream", "ORDERS")
+	assertString(t, rows[0], "name", "PROCESSOR")
+}
+
+func TestConsumerConfigsListByStream(t *testing.T) {
+	rows := query(t, "consumer_configs", consumerConfigTestColumns, map[string]string{"stream": "SHIPMENTS"})
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	for _, r := range rows {
+		assertString(t, r, "stream", "SHIPMENTS")
+	}
+	requireRow(t, rows, "name", "PROCESSOR")
+	requireRow(t, rows, "name", "TRACKER")
+}
@@ -14,13 +14,14 @@ func consumerInfo() *plugin.Table {
 		Name:        "consumer_info",
 		Description: "The consumer info",
 		List: &plugin.ListConfig{
-			Hydrate: listConsumerInfos,
+			KeyColumns: consumerListKeyColumns(),
+			Hydrate:    listConsumerInfos,
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
+			KeyColumns: plugin.AllColumns([]string{"name", "stream"}),
 			Hydrate:    getConsumerInfo,
 		},
-		Columns: []*plugin.Column{
+		Columns: append([]*plugin.Column{
 			{Name: "name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name")},
 			{Name: "stream", Type: proto.ColumnType_STRING, Transform: transform.FromField("Stream")},
 			{Name: "created", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Created")},
@@ -35,77 +36,54 @@ func consumerInfo() *plugin.Table {
 			{Name: "num_pending", Type: proto.ColumnType_INT, Transform: transform.FromField("NumPending")},
 			{Name: "cluster_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Cluster.Name")},
 			{Name: "push_bound", Type: proto.ColumnType_BOOL, Transform: transform.FromField("PushBound")},
-		},
+		}, consumerConfigColumns()...),
 	}
 }
 
 func listConsumerInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
 
-	nc, err := config.Connect()
-	if err != nil {
-		return nil, err
-	}
-
-	manager, err := jsm.New(nc)
-	if err != nil {
-		return nil, err
-	}
-
-	var streams []string
+	err = eachConsumer(ctx, d, manager, func(v *jsm.Consumer) error {
+		// Consumer lists hold the info of each consumer, which LatestState
+		// returns without another API request. Only the per stream lists in
+		// eachConsumer are fanned out.
+		info, err := v.LatestState()
+		if err != nil {
+			return consumerError(v.StreamName(), v.Name(), err)
+		}
 
-	err = manager.EachStream(func(s *jsm.Stream) {
-		streams = append(streams, s.Configuration().Name)
+		d.StreamListItem(ctx, info)
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, s := range streams {
-		consumers, err := manager.Consumers(s)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, v := range consumers {
-			info, err := v.LatestState()
-			if err != nil {
-				return nil, err
-			}
-
-			d.StreamListItem(ctx, info)
-		}
-	}
-
 	return nil, nil
 
 }
 
 func getConsumerInfo(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
 
-	nc, err := config.Connect()
-	if err != nil {
-		return nil, err
-	}
+	stream := d.KeyColumnQuals["stream"].GetStringValue()
+	name := d.KeyColumnQuals["name"].GetStringValue()
 
-	manager, err := jsm.New(nc)
+	consumer, err := manager.LoadConsumer(stream, name)
 	if err != nil {
-		return nil, err
+		return nil, consumerError(stream, name, err)
 	}
 
-	name := d.KeyColumnQuals["name"].GetStringValue()
-
-	stream, err := manager.LoadStream(name)
+	info, err := consumer.LatestState()
 	if err != nil {
-		return nil, err
+		return nil, consumerError(stream, name, err)
 	}
 
-	return stream.LatestInformation()
+	return info, nil
 }
@@ -0,0 +1,63 @@
+package nats
+
+import "testing"
+
+var consumerInfoColumns = []string{"stream", "name", "num_pending", "num_ack_pending", "durable", "filter_subject", "ack_policy"}
+
+func TestConsumerInfoList(t *testing.T) {
+	rows := query(t, "consumer_info", consumerInfoColumns, nil)
+
+	r := requireRow(t, rows, "stream", "ORDERS", "name", "PROCESSOR")
+	assertInt(t, r, "num_pending", 3)
+	assertInt(t, r, "num_ack_pending", 0)
+}
+
+func TestConsumerInfoGet(t *testing.T) {
+	rows := query(t, "consumer_info", consumerInfoColumns, map[string]string{"stream": "ORDERS", "name": "PROCESSOR"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "stream", "ORDERS")
+	assertString(t, rows[0], "name", "PROCESSOR")
+	assertInt(t, rows[0], "num_pending", 3)
+	assertString(t, rows[0], "durable", "PROCESSOR")
+	assertString(t, rows[0], "filter_subject", "orders.new")
+	assertString(t, rows[0], "ack_policy", "Explicit")
+}
+
+func TestConsumerInfoListByNames(t *testing.T) {
+	rows := queryQuals(t, "consumer_info", consumerInfoColumns, qual("name", "=", listValue("TRACKER", "MISSING")))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "stream", "SHIPMENTS")
+	assertString(t, rows[0], "name", "TRACKER")
+}
+
+func TestConsumerInfoListByStreams(t *testing.T) {
+	rows := queryQuals(t, "consumer_info", consumerInfoColumns, qual("stream", "=", listValue("ORDERS", "SHIPMENTS")))
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+}
+
+func TestConsumerInfoGetMissing(t *testing.T) {
+	for _, quals := range []map[string]string{
+		{"stream": "ORDERS", "name": "MISSING"},
+		{"stream": "MISSING", "name": "PROCESSOR"},
+	} {
+		rows := query(t, "consumer_info", consumerInfoColumns, quals)
+		if len(rows) != 0 {
+			t.Fatalf("expected no rows for %v, got %d", quals, len(rows))
+		}
+	}
+}
+
+func TestConsumerInfoListMissingStream(t *testing.T) {
+	rows := query(t, "consumer_info", consumerInfoColumns, map[string]string{"stream": "MISSING"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
@@ -0,0 +1,214 @@
+package nats
+
+import (
+	"context"
+	"net/url"
+	"sort"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func gatewayzInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "gatewayz_info",
+		Description: "The outbound and inbound gateway connections of every monitored server",
+		List: &plugin.ListConfig{
+			KeyColumns: append(serverKeyColumns(), plugin.OptionalColumns([]string{"remote_gateway"})...),
+			Hydrate:    listGatewayzInfos,
+		},
+		Columns: append(gatewayColumns(),
+			&plugin.Column{Name: "is_configured", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IsConfigured")},
+			&plugin.Column{Name: "ip", Type: proto.ColumnType_STRING, Transform: transform.FromField("Connection.IP")},
+			&plugin.Column{Name: "port", Type: proto.ColumnType_INT, Transform: transform.FromField("Connection.Port")},
+			&plugin.Column{Name: "remote_server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("Connection.Name")},
+			&plugin.Column{Name: "start", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Connection.Start")},
+			&plugin.Column{Name: "last_activity", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Connection.LastActivity")},
+			&plugin.Column{Name: "rtt", Type: proto.ColumnType_STRING, Transform: transform.FromField("Connection.RTT")},
+			&plugin.Column{Name: "uptime", Type: proto.ColumnType_STRING, Transform: transform.FromField("Connection.Uptime")},
+			&plugin.Column{Name: "idle", Type: proto.ColumnType_STRING, Transform: transform.FromField("Connection.Idle")},
+			&plugin.Column{Name: "pending_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Connection.Pending")},
+			&plugin.Column{Name: "in_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("Connection.InMsgs")},
+			&plugin.Column{Name: "out_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("Connection.OutMsgs")},
+			&plugin.Column{Name: "in_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Connection.InBytes")},
+			&plugin.Column{Name: "out_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Connection.OutBytes")},
+			&plugin.Column{Name: "subscriptions", Type: proto.ColumnType_INT, Transform: transform.FromField("Connection.NumSubs")},
+			&plugin.Column{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			&plugin.Column{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		),
+	}
+}
+
+func gatewayzAccounts() *plugin.Table {
+	return &plugin.Table{
+		Name:        "gatewayz_accounts",
+		Description: "The per account interest of every gateway connection of every monitored server",
+		List: &plugin.ListConfig{
+			KeyColumns: append(serverKeyColumns(), plugin.OptionalColumns([]string{"remote_gateway", "account"})...),
+			Hydrate:    listGatewayzAccounts,
+		},
+		Columns: append(gatewayColumns(),
+			&plugin.Column{Name: "account", Type: proto.ColumnType_STRING, Transform: transform.FromField("Account.Name")},
+			&plugin.Column{Name: "interest_mode", Type: proto.ColumnType_STRING, Transform: transform.FromField("Account.InterestMode")},
+			&plugin.Column{Name: "no_interest_count", Type: proto.ColumnType_INT, Transform: transform.FromField("Account.NoInterestCount")},
+			&plugin.Column{Name: "interest_only_threshold", Type: proto.ColumnType_INT, Transform: transform.FromField("Account.InterestOnlyThreshold")},
+			&plugin.Column{Name: "subscriptions", Type: proto.ColumnType_INT, Transform: transform.FromField("Account.TotalSubscriptions")},
+			&plugin.Column{Name: "queue_subscriptions", Type: proto.ColumnType_INT, Transform: transform.FromField("Account.NumQueueSubscriptions")},
+			&plugin.Column{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			&plugin.Column{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		),
+	}
+}
+
+// gatewayColumns are the columns identifying a gateway connection, shared by
+// both gatewayz tables.
+func gatewayColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerID")},
+		{Name: "server_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerName")},
+		{Name: "gateway", Type: proto.ColumnType_STRING, Transform: transform.FromField("Gateway")},
+		{Name: "gateway_host", Type: proto.ColumnType_STRING, Transform: transform.FromField("GatewayHost")},
+		{Name: "gateway_port", Type: proto.ColumnType_INT, Transform: transform.FromField("GatewayPort")},
+		{Name: "direction", Type: proto.ColumnType_STRING, Transform: transform.FromField("Direction")},
+		{Name: "remote_gateway", Type: proto.ColumnType_STRING, Transform: transform.FromField("RemoteGateway")},
+		{Name: "cid", Type: proto.ColumnType_INT, Transform: transform.FromField("Connection.Cid")},
+	}
+}
+
+// GatewayzInfo is a single outbound or inbound gateway connection of a
+// server, or the error fetching the gateways of a server.
+type GatewayzInfo struct {
+	server.RemoteGatewayz
+	ServerID      string `json:"server_id"`
+	ServerName    string `json:"server_name"`
+	Gateway       string `json:"gateway"`
+	GatewayHost   string `json:"gateway_host"`
+	GatewayPort   int    `json:"gateway_port"`
+	Direction     string `json:"direction"`
+	RemoteGateway string `json:"remote_gateway"`
+	SourceURL     string `json:"source_url"`
+	Error         string `json:"error"`
+}
+
+// GatewayzAccount is the interest state of one account on a gateway
+// connection.
+type GatewayzAccount struct {
+	GatewayzInfo
+	Account *server.AccountGatewayz `json:"account"`
+}
+
+func listGatewayzInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	err := eachGateway(ctx, d, false, func(gw GatewayzInfo) {
+		gw.Accounts = nil
+		d.StreamListItem(ctx, gw)
+	})
+	return nil, err
+}
+
+func listGatewayzAccounts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	err := eachGateway(ctx, d, true, func(gw GatewayzInfo) {
+		if gw.Error != "" {
+			d.StreamListItem(ctx, GatewayzAccount{GatewayzInfo: gw})
+			return
+		}
+		for _, acc := range gw.Accounts {
+			d.StreamListItem(ctx, GatewayzAccount{GatewayzInfo: gw, Account: acc})
+		}
+	})
+	return nil, err
+}
+
+// eachGateway calls cb for every gateway connection of every server matching
+// the server quals, outbound before inbound and by remote gateway name. The
+// remote_gateway and account quals are sent as gatewayz options. Servers that
+// could not be queried are passed to cb as a GatewayzInfo with Error set.
+func eachGateway(ctx context.Context, d *plugin.QueryData, accounts bool, cb func(GatewayzInfo)) error {
+	monitor, err := getMonitor(d)
+	if err != nil {
+		return err
+	}
+
+	results, err := fetchServers[server.Gatewayz](ctx, monitor, gatewayzRequest(d, accounts))
+	if err != nil {
+		return err
+	}
+
+	servers := lookupServers(ctx, monitor, results)
+
+	for _, r := range results {
+		name := servers.name(r.Value.ID)
+		if !matchesServerQuals(d, r.Value.ID, name) {
+			continue
+		}
+
+		if r.Err != nil {
+			cb(GatewayzInfo{SourceURL: r.URL, Error: errorString(r.Err)})
+			continue
+		}
+
+		gw := GatewayzInfo{
+			ServerID:    r.Value.ID,
+			ServerName:  name,
+			Gateway:     r.Value.Name,
+			GatewayHost: r.Value.Host,
+			GatewayPort: r.Value.Port,
+			SourceURL:   r.URL,
+		}
+
+		for _, remote := range sortedKeys(r.Value.OutboundGateways) {
+			gw.Direction = "outbound"
+			gw.RemoteGateway = remote
+			gw.RemoteGatewayz = *r.Value.OutboundGateways[remote]
+			cb(gw)
+		}
+
+		for _, remote := range sortedKeys(r.Value.InboundGateways) {
+			for _, in := range r.Value.InboundGateways[remote] {
+				gw.Direction = "inbound"
+				gw.RemoteGateway = remote
+				gw.RemoteGatewayz = *in
+				cb(gw)
+			}
+		}
+	}
+
+	return nil
+}
+
+func gatewayzRequest(d *plugin.QueryData, accounts bool) monitoringRequest {
+	opts := server.GatewayzOptions{Accounts: accounts}
+	if q := d.KeyColumnQuals["remote_gateway"]; q != nil {
+		opts.Name = q.GetStringValue()
+	}
+	if q := d.KeyColumnQuals["account"]; q != nil {
+		opts.AccountName = q.GetStringValue()
+	}
+
+	query := url.Values{}
+	if opts.Accounts {
+		query.Set("accs", "true")
+	}
+	if opts.Name != "" {
+		query.Set("gw_name", opts.Name)
+	}
+	if opts.AccountName != "" {
+		query.Set("acc_name", opts.AccountName)
+	}
+
+	return monitoringRequest{
+		Endpoint: "gatewayz",
+		Query:    query,
+		Options:  server.GatewayzEventOptions{GatewayzOptions: opts},
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
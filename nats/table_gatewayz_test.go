@@ -0,0 +1,133 @@
+package nats
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+var gatewayzTestColumns = []string{"server_id", "server_name", "gateway", "direction", "remote_gateway", "is_configured", "remote_server_id", "source_url", "error"}
+
+var gatewayzAccountsTestColumns = []string{"server_name", "direction", "remote_gateway", "account", "interest_mode"}
+
+const testGatewayConfig = `
+server_name: %s
+listen: 127.0.0.1:-1
+http: 127.0.0.1:-1
+gateway {
+  name: %s
+  listen: 127.0.0.1:-1
+  gateways: [%s]
+}
+`
+
+// runSuperCluster starts the single server gateways east and west, with west
+// configured to connect to east.
+func runSuperCluster(t *testing.T) (*server.Server, *server.Server) {
+	t.Helper()
+
+	east := runServer(t, fmt.Sprintf(testGatewayConfig, "server-east", "east", ""))
+	west := runServer(t, fmt.Sprintf(testGatewayConfig, "server-west", "west",
+		fmt.Sprintf("{name: east, url: %q}", fmt.Sprintf("nats://%s", east.GatewayAddr()))))
+
+	waitFor(t, "gateways", func() bool {
+		return east.NumOutboundGateways() == 1 && west.NumOutboundGateways() == 1
+	})
+
+	return east, west
+}
+
+func superClusterConfig(servers ...*server.Server) string {
+	return fmt.Sprintf("monitoring_urls = [%q, %q]", monitoringURL(servers[0]), monitoringURL(servers[1]))
+}
+
+func TestGatewayzInfoList(t *testing.T) {
+	east, west := runSuperCluster(t)
+
+	rows := queryConfig(t, superClusterConfig(east, west), "gatewayz_info", gatewayzTestColumns)
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+
+	r := requireRow(t, rows, "server_name", "server-west", "direction", "outbound")
+	assertString(t, r, "server_id", west.ID())
+	assertString(t, r, "gateway", "west")
+	assertString(t, r, "remote_gateway", "east")
+	assertString(t, r, "remote_server_id", east.ID())
+	assertBool(t, r, "is_configured", true)
+	assertString(t, r, "source_url", monitoringURL(west))
+	assertString(t, r, "error", "")
+
+	r = requireRow(t, rows, "server_name", "server-east", "direction", "outbound")
+	assertString(t, r, "remote_gateway", "west")
+	assertBool(t, r, "is_configured", false)
+
+	requireRow(t, rows, "server_name", "server-east", "direction", "inbound", "remote_gateway", "west")
+	requireRow(t, rows, "server_name", "server-west", "direction", "inbound", "remote_gateway", "east")
+}
+
+func TestGatewayzInfoFilter(t *testing.T) {
+	east, west := runSuperCluster(t)
+
+	rows := queryConfig(t, superClusterConfig(east, west), "gatewayz_info", gatewayzTestColumns,
+		qual("remote_gateway", "=", stringValue("east")))
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	for _, r := range rows {
+		assertString(t, r, "server_name", "server-west")
+	}
+}
+
+func TestGatewayzAccounts(t *testing.T) {
+	east, west := runSuperCluster(t)
+
+	// Publishing on east without subscribers on west makes east track the
+	// interest of west in the global account.
+	nc, err := nats.Connect(east.ClientURL())
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer nc.Close()
+
+	config := superClusterConfig(east, west)
+	var rows []testRow
+	waitFor(t, "account interest", func() bool {
+		if err := nc.Publish("gatewayz.test", nil); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+		rows = queryConfig(t, config, "gatewayz_accounts", gatewayzAccountsTestColumns,
+			qual("account", "=", stringValue("$G")))
+		return findRow(rows, "server_name", "server-east", "direction", "outbound") != nil
+	})
+
+	r := requireRow(t, rows, "server_name", "server-east", "direction", "outbound")
+	assertString(t, r, "remote_gateway", "west")
+	if r["interest_mode"].GetStringValue() == "" {
+		t.Errorf("expected an interest mode")
+	}
+}
+
+func TestGatewayzInfoNoGateways(t *testing.T) {
+	rows := query(t, "gatewayz_info", gatewayzTestColumns, nil)
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
+
+func TestGatewayzInfoUnreachableServer(t *testing.T) {
+	east, _ := runSuperCluster(t)
+
+	config := fmt.Sprintf("monitoring_urls = [%q, %q]", monitoringURL(east), "http://127.0.0.1:1")
+	rows := queryConfig(t, config, "gatewayz_info", gatewayzTestColumns)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	r := requireRow(t, rows, "source_url", "http://127.0.0.1:1")
+	if r["error"].GetStringValue() == "" {
+		t.Errorf("expected an error for the unreachable server")
+	}
+}
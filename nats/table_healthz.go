@@ -0,0 +1,136 @@
+package nats
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func healthzInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "healthz",
+		Description: "The health of every monitored server",
+		List: &plugin.ListConfig{
+			KeyColumns: append(serverKeyColumns(), plugin.OptionalColumns([]string{"js_enabled_only", "js_server_only"})...),
+			Hydrate:    listHealthz,
+		},
+		Columns: []*plugin.Column{
+			{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerID")},
+			{Name: "server_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerName")},
+			{Name: "status", Type: proto.ColumnType_STRING, Transform: transform.FromField("Status")},
+			{Name: "status_code", Type: proto.ColumnType_INT, Transform: transform.FromField("StatusCode")},
+			{Name: "health_error", Type: proto.ColumnType_STRING, Transform: transform.FromField("HealthStatus.Error")},
+			{Name: "details", Type: proto.ColumnType_JSON, Transform: transform.FromField("Details")},
+			{Name: "js_enabled_only", Type: proto.ColumnType_BOOL, Transform: transform.FromField("JSEnabledOnly")},
+			{Name: "js_server_only", Type: proto.ColumnType_BOOL, Transform: transform.FromField("JSServerOnly")},
+			{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		},
+	}
+}
+
+// healthStatus is server.HealthStatus with the status code and the per stream
+// and consumer errors of newer servers.
+type healthStatus struct {
+	server.HealthStatus
+	StatusCode int            `json:"status_code"`
+	Details    []healthzError `json:"errors"`
+}
+
+// healthzError is a single failed check reported by newer servers.
+type healthzError struct {
+	Type     string `json:"type"`
+	Account  string `json:"account,omitempty"`
+	Stream   string `json:"stream,omitempty"`
+	Consumer string `json:"consumer,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// healthzOptions sends both the js-enabled option of 2.9 servers and the
+// js-enabled-only option that replaced it.
+type healthzOptions struct {
+	JSEnabled     bool `json:"js-enabled,omitempty"`
+	JSEnabledOnly bool `json:"js-enabled-only,omitempty"`
+	JSServerOnly  bool `json:"js-server-only,omitempty"`
+}
+
+// Healthz is the health of a single server, or the error querying it. A server
+// that answered but is unhealthy has its failure in health_error, while error
+// is only set when it could not be asked at all.
+type Healthz struct {
+	healthStatus
+	ServerID      string `json:"server_id"`
+	ServerName    string `json:"server_name"`
+	JSEnabledOnly bool   `json:"js_enabled_only"`
+	JSServerOnly  bool   `json:"js_server_only"`
+	SourceURL     string `json:"source_url"`
+	Error         string `json:"error"`
+}
+
+func listHealthz(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	monitor, err := getMonitor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := healthzOptions{}
+	if q := d.KeyColumnQuals["js_enabled_only"]; q != nil {
+		opts.JSEnabledOnly = q.GetBoolValue()
+		opts.JSEnabled = opts.JSEnabledOnly
+	}
+	if q := d.KeyColumnQuals["js_server_only"]; q != nil {
+		opts.JSServerOnly = q.GetBoolValue()
+	}
+
+	query := url.Values{}
+	if opts.JSEnabledOnly {
+		query.Set("js-enabled", "true")
+		query.Set("js-enabled-only", "true")
+	}
+	if opts.JSServerOnly {
+		query.Set("js-server-only", "true")
+	}
+
+	// Unhealthy servers answer HTTP requests with a 503 and their status.
+	results, err := fetchServers[healthStatus](ctx, monitor, monitoringRequest{
+		Endpoint:  "healthz",
+		Query:     query,
+		Options:   opts,
+		AnyStatus: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	servers := lookupServers(ctx, monitor, results)
+
+	for _, r := range results {
+		// The health status does not identify the server, so it is looked up
+		// by where the response came from.
+		id := servers.id(r.Target)
+		name := servers.name(id)
+		if !matchesServerQuals(d, id, name) {
+			continue
+		}
+
+		row := Healthz{
+			ServerID:      id,
+			ServerName:    name,
+			JSEnabledOnly: opts.JSEnabledOnly,
+			JSServerOnly:  opts.JSServerOnly,
+			SourceURL:     r.URL,
+			Error:         errorString(r.Err),
+		}
+		if r.Err == nil {
+			row.healthStatus = r.Value
+		}
+
+		d.StreamListItem(ctx, row)
+	}
+
+	return nil, nil
+}
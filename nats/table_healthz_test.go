@@ -0,0 +1,54 @@
+package nats
+
+import (
+	"fmt"
+	"testing"
+)
+
+var healthzTestColumns = []string{"server_id", "server_name", "status", "health_error", "js_enabled_only", "source_url", "error"}
+
+func TestHealthzList(t *testing.T) {
+	rows := query(t, "healthz", healthzTestColumns, nil)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "server_name", "test-server")
+	assertString(t, rows[0], "status", "ok")
+	assertString(t, rows[0], "health_error", "")
+	assertString(t, rows[0], "source_url", testMonitoringURL())
+	assertString(t, rows[0], "error", "")
+}
+
+func TestHealthzJetStreamDisabled(t *testing.T) {
+	s := runServer(t, `
+server_name: no-jetstream
+listen: 127.0.0.1:-1
+http: 127.0.0.1:-1
+`)
+
+	config := fmt.Sprintf("monitoring_url = %q", monitoringURL(s))
+	rows := queryConfig(t, config, "healthz", healthzTestColumns, qual("js_enabled_only", "=", boolValue(true)))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_name", "no-jetstream")
+	assertString(t, rows[0], "status", "unavailable")
+	assertBool(t, rows[0], "js_enabled_only", true)
+	assertString(t, rows[0], "error", "")
+	if rows[0]["health_error"].GetStringValue() == "" {
+		t.Errorf("expected a health error")
+	}
+}
+
+func TestHealthzSystemSource(t *testing.T) {
+	rows := queryConnection(t, testSystemConnection, "healthz", healthzTestColumns, qual("js_enabled_only", "=", boolValue(true)))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "status", "ok")
+}
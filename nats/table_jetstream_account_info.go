@@ -0,0 +1,100 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func jetStreamAccountInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "jetstream_account_info",
+		Description: "The JetStream usage and limits of the connected account",
+		List: &plugin.ListConfig{
+			Hydrate: listJetStreamAccountInfo,
+		},
+		Columns: []*plugin.Column{
+			{Name: "tier", Type: proto.ColumnType_STRING, Transform: transform.FromField("Tier")},
+			{Name: "domain", Type: proto.ColumnType_STRING, Transform: transform.FromField("Domain")},
+			{Name: "memory", Type: proto.ColumnType_INT, Transform: transform.FromField("Memory")},
+			{Name: "max_memory", Type: proto.ColumnType_INT, Transform: transform.FromField("Limits.MaxMemory")},
+			{Name: "memory_used_percent", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("MemoryUsedPercent")},
+			{Name: "storage", Type: proto.ColumnType_INT, Transform: transform.FromField("Store")},
+			{Name: "max_storage", Type: proto.ColumnType_INT, Transform: transform.FromField("Limits.MaxStore")},
+			{Name: "storage_used_percent", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("StorageUsedPercent")},
+			{Name: "streams", Type: proto.ColumnType_INT, Transform: transform.FromField("Streams")},
+			{Name: "max_streams", Type: proto.ColumnType_INT, Transform: transform.FromField("Limits.MaxStreams")},
+			{Name: "streams_used_percent", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("StreamsUsedPercent")},
+			{Name: "consumers", Type: proto.ColumnType_INT, Transform: transform.FromField("Consumers")},
+			{Name: "max_consumers", Type: proto.ColumnType_INT, Transform: transform.FromField("Limits.MaxConsumers")},
+			{Name: "consumers_used_percent", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("ConsumersUsedPercent")},
+			{Name: "max_ack_pending", Type: proto.ColumnType_INT, Transform: transform.FromField("Limits.MaxAckPending")},
+			{Name: "memory_max_stream_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Limits.MemoryMaxStreamBytes")},
+			{Name: "storage_max_stream_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Limits.StoreMaxStreamBytes")},
+			{Name: "max_bytes_required", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Limits.MaxBytesRequired")},
+			{Name: "api_total", Type: proto.ColumnType_INT, Transform: transform.FromField("API.Total")},
+			{Name: "api_errors", Type: proto.ColumnType_INT, Transform: transform.FromField("API.Errors")},
+		},
+	}
+}
+
+// JetStreamAccountInfo is the usage of the account, or of one of its tiers
+// such as R1 or R3 when the account has tiered limits. The account row has no
+// tier. Percentages are only set for limited resources, as a limit of -1
+// means unlimited.
+type JetStreamAccountInfo struct {
+	api.JetStreamTier
+	Tier                 string                `json:"tier"`
+	Domain               string                `json:"domain"`
+	API                  api.JetStreamAPIStats `json:"api"`
+	MemoryUsedPercent    *float64              `json:"memory_used_percent"`
+	StorageUsedPercent   *float64              `json:"storage_used_percent"`
+	StreamsUsedPercent   *float64              `json:"streams_used_percent"`
+	ConsumersUsedPercent *float64              `json:"consumers_used_percent"`
+}
+
+func newJetStreamAccountInfo(tier string, usage api.JetStreamTier, stats *api.JetStreamAccountStats) JetStreamAccountInfo {
+	return JetStreamAccountInfo{
+		JetStreamTier:        usage,
+		Tier:                 tier,
+		Domain:               stats.Domain,
+		API:                  stats.API,
+		MemoryUsedPercent:    usedPercent(float64(usage.Memory), float64(usage.Limits.MaxMemory)),
+		StorageUsedPercent:   usedPercent(float64(usage.Store), float64(usage.Limits.MaxStore)),
+		StreamsUsedPercent:   usedPercent(float64(usage.Streams), float64(usage.Limits.MaxStreams)),
+		ConsumersUsedPercent: usedPercent(float64(usage.Consumers), float64(usage.Limits.MaxConsumers)),
+	}
+}
+
+// usedPercent returns how much of limit is used, or nil when there is no limit.
+func usedPercent(used, limit float64) *float64 {
+	if limit <= 0 {
+		return nil
+	}
+
+	percent := used / limit * 100
+	return &percent
+}
+
+func listJetStreamAccountInfo(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	manager, err := getManager(d)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := manager.JetStreamAccountInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	d.StreamListItem(ctx, newJetStreamAccountInfo("", stats.JetStreamTier, stats))
+
+	for _, tier := range sortedKeys(stats.Tiers) {
+		d.StreamListItem(ctx, newJetStreamAccountInfo(tier, stats.Tiers[tier], stats))
+	}
+
+	return nil, nil
+}
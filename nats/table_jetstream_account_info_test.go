@@ -0,0 +1,82 @@
+package nats
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+)
+
+var jetStreamAccountInfoTestColumns = []string{"tier", "domain", "memory", "max_memory", "memory_used_percent", "storage", "max_storage", "storage_used_percent", "streams", "max_streams", "streams_used_percent", "consumers", "max_consumers", "consumers_used_percent", "api_total", "api_errors"}
+
+func TestJetStreamAccountInfoList(t *testing.T) {
+	rows := query(t, "jetstream_account_info", jetStreamAccountInfoTestColumns, nil)
+	if len(rows) != 1 {
+		t.Fatalf("expected only the account row, got %d rows", len(rows))
+	}
+
+	r := rows[0]
+	assertString(t, r, "tier", "")
+	assertInt(t, r, "max_streams", -1)
+	if r["streams"].GetIntValue() < 2 {
+		t.Errorf("expected the fixture streams to be counted, got %d", r["streams"].GetIntValue())
+	}
+	if r["api_total"].GetIntValue() == 0 {
+		t.Errorf("expected api requests to be counted")
+	}
+	if _, null := r["streams_used_percent"].GetValue().(*proto.Column_NullValue); !null {
+		t.Errorf("expected no percentage for an unlimited account")
+	}
+}
+
+func TestJetStreamAccountInfoLimits(t *testing.T) {
+	s := runServer(t, fmt.Sprintf(`
+server_name: limited
+listen: 127.0.0.1:-1
+jetstream {
+  store_dir: %q
+}
+accounts {
+  LIMITED {
+    jetstream: {max_mem: 1M, max_file: 10M, max_streams: 4, max_consumers: 10}
+    users: [{user: limited, password: limited}]
+  }
+}
+`, t.TempDir()))
+
+	nc, err := nats.Connect(s.ClientURL(), nats.UserInfo("limited", "limited"))
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer nc.Close()
+
+	manager, err := jsm.New(nc)
+	if err != nil {
+		t.Fatalf("manager failed: %v", err)
+	}
+	if _, err := manager.NewStream("LIMITED", jsm.Subjects("limited.>"), jsm.MemoryStorage(), jsm.MaxBytes(1024)); err != nil {
+		t.Fatalf("create stream failed: %v", err)
+	}
+
+	config := fmt.Sprintf("urls = %q\nusername = \"limited\"\npassword = \"limited\"", s.ClientURL())
+	rows := queryConfig(t, config, "jetstream_account_info", jetStreamAccountInfoTestColumns)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	r := rows[0]
+	assertInt(t, r, "streams", 1)
+	assertInt(t, r, "max_streams", 4)
+	assertInt(t, r, "max_consumers", 10)
+	if v := r["streams_used_percent"].GetDoubleValue(); v != 25 {
+		t.Errorf("expected streams_used_percent to be 25, got %v", v)
+	}
+	if v := r["consumers_used_percent"].GetDoubleValue(); v != 0 {
+		t.Errorf("expected consumers_used_percent to be 0, got %v", v)
+	}
+	if _, null := r["memory_used_percent"].GetValue().(*proto.Column_NullValue); null {
+		t.Errorf("expected memory_used_percent to be set")
+	}
+}
@@ -0,0 +1,141 @@
+package nats
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strconv"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// jszLimit asks servers for the details of all their JetStream accounts at
+// once, as their order is not stable between requests.
+const jszLimit = math.MaxInt32
+
+func jszInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "jsz_info",
+		Description: "The JetStream state of every monitored server",
+		List: &plugin.ListConfig{
+			KeyColumns: append(serverKeyColumns(), plugin.OptionalColumns([]string{"include_accounts", "include_streams", "include_consumers"})...),
+			Hydrate:    listJszInfos,
+		},
+		Columns: []*plugin.Column{
+			{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID")},
+			{Name: "server_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerName")},
+			{Name: "disabled", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Disabled")},
+			{Name: "domain", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.Domain")},
+			{Name: "store_dir", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.StoreDir")},
+			{Name: "max_memory", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxMemory")},
+			{Name: "max_storage", Type: proto.ColumnType_INT, Transform: transform.FromField("Config.MaxStore")},
+			{Name: "memory", Type: proto.ColumnType_INT, Transform: transform.FromField("Memory")},
+			{Name: "storage", Type: proto.ColumnType_INT, Transform: transform.FromField("Store")},
+			{Name: "reserved_memory", Type: proto.ColumnType_INT, Transform: transform.FromField("ReservedMemory")},
+			{Name: "reserved_storage", Type: proto.ColumnType_INT, Transform: transform.FromField("ReservedStore")},
+			{Name: "accounts", Type: proto.ColumnType_INT, Transform: transform.FromField("Accounts")},
+			{Name: "ha_assets", Type: proto.ColumnType_INT, Transform: transform.FromField("HAAssets")},
+			{Name: "streams", Type: proto.ColumnType_INT, Transform: transform.FromField("Streams")},
+			{Name: "consumers", Type: proto.ColumnType_INT, Transform: transform.FromField("Consumers")},
+			{Name: "messages", Type: proto.ColumnType_INT, Transform: transform.FromField("Messages")},
+			{Name: "bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("Bytes")},
+			{Name: "api_total", Type: proto.ColumnType_INT, Transform: transform.FromField("API.Total")},
+			{Name: "api_errors", Type: proto.ColumnType_INT, Transform: transform.FromField("API.Errors")},
+			{Name: "api_inflight", Type: proto.ColumnType_INT, Transform: transform.FromField("API.Inflight")},
+			{Name: "meta_cluster", Type: proto.ColumnType_STRING, Transform: transform.FromField("Meta.Name")},
+			{Name: "meta_leader", Type: proto.ColumnType_STRING, Transform: transform.FromField("Meta.Leader")},
+			{Name: "meta_cluster_size", Type: proto.ColumnType_INT, Transform: transform.FromField("Meta.Size")},
+			{Name: "meta_replicas", Type: proto.ColumnType_JSON, Transform: transform.FromField("Meta.Replicas")},
+			{Name: "include_accounts", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IncludeAccounts")},
+			{Name: "include_streams", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IncludeStreams")},
+			{Name: "include_consumers", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IncludeConsumers")},
+			{Name: "account_details", Type: proto.ColumnType_JSON, Transform: transform.FromField("AccountDetails")},
+			{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		},
+	}
+}
+
+// JszInfo is the JetStream state of a server, or the error fetching it.
+// account_details is filled in when the query sets one of the include quals,
+// each adding a level of detail: accounts, their streams, and the consumers
+// of those streams.
+type JszInfo struct {
+	server.JSInfo
+	ServerName       string `json:"server_name"`
+	IncludeAccounts  bool   `json:"include_accounts"`
+	IncludeStreams   bool   `json:"include_streams"`
+	IncludeConsumers bool   `json:"include_consumers"`
+	SourceURL        string `json:"source_url"`
+	Error            string `json:"error"`
+}
+
+func listJszInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	monitor, err := getMonitor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	row := JszInfo{}
+	if q := d.KeyColumnQuals["include_accounts"]; q != nil {
+		row.IncludeAccounts = q.GetBoolValue()
+	}
+	if q := d.KeyColumnQuals["include_streams"]; q != nil {
+		row.IncludeStreams = q.GetBoolValue()
+	}
+	if q := d.KeyColumnQuals["include_consumers"]; q != nil {
+		row.IncludeConsumers = q.GetBoolValue()
+	}
+
+	opts := server.JSzOptions{
+		Accounts: row.IncludeAccounts || row.IncludeStreams || row.IncludeConsumers,
+		Streams:  row.IncludeStreams || row.IncludeConsumers,
+		Consumer: row.IncludeConsumers,
+		Limit:    jszLimit,
+	}
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(opts.Limit))
+	if opts.Accounts {
+		query.Set("accounts", "true")
+	}
+	if opts.Streams {
+		query.Set("streams", "true")
+	}
+	if opts.Consumer {
+		query.Set("consumers", "true")
+	}
+
+	results, err := fetchServers[server.JSInfo](ctx, monitor, monitoringRequest{
+		Endpoint: "jsz",
+		Query:    query,
+		Options:  server.JszEventOptions{JSzOptions: opts},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	servers := lookupServers(ctx, monitor, results)
+
+	for _, r := range results {
+		name := servers.name(r.Value.ID)
+		if !matchesServerQuals(d, r.Value.ID, name) {
+			continue
+		}
+
+		if r.Err != nil {
+			d.StreamListItem(ctx, JszInfo{SourceURL: r.URL, Error: errorString(r.Err)})
+			continue
+		}
+
+		row.JSInfo = r.Value
+		row.ServerName = name
+		row.SourceURL = r.URL
+		d.StreamListItem(ctx, row)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+package nats
+
+import (
+	"strings"
+	"testing"
+)
+
+var jszTestColumns = []string{"server_id", "server_name", "disabled", "streams", "consumers", "messages", "max_storage", "include_streams", "account_details", "source_url", "error"}
+
+func TestJszInfoList(t *testing.T) {
+	rows := query(t, "jsz_info", jszTestColumns, nil)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	r := rows[0]
+	assertString(t, r, "server_id", testServer.ID())
+	assertString(t, r, "server_name", "test-server")
+	assertBool(t, r, "disabled", false)
+	assertString(t, r, "source_url", testMonitoringURL())
+	assertString(t, r, "error", "")
+	if r["streams"].GetIntValue() < 2 {
+		t.Errorf("expected at least the ORDERS and SHIPMENTS streams, got %d", r["streams"].GetIntValue())
+	}
+	if r["max_storage"].GetIntValue() <= 0 {
+		t.Errorf("expected a storage limit")
+	}
+	if details := string(r["account_details"].GetJsonValue()); details != "" && details != "null" {
+		t.Errorf("expected no account details, got %s", details)
+	}
+}
+
+func TestJszInfoDetail(t *testing.T) {
+	rows := queryQuals(t, "jsz_info", jszTestColumns, qual("include_streams", "=", boolValue(true)))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertBool(t, rows[0], "include_streams", true)
+	details := string(rows[0]["account_details"].GetJsonValue())
+	for _, expected := range []string{`"APP"`, `"ORDERS"`} {
+		if !strings.Contains(details, expected) {
+			t.Errorf("expected account details to contain %s, got %s", expected, details)
+		}
+	}
+}
+
+func TestJszInfoSystemSource(t *testing.T) {
+	rows := queryConnection(t, testSystemConnection, "jsz_info", jszTestColumns)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "server_name", "test-server")
+}
@@ -0,0 +1,138 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// kvOperationHeader is the header marking KV deletes and purges.
+const kvOperationHeader = "KV-Operation"
+
+func kvEntries() *plugin.Table {
+	return &plugin.Table{
+		Name:        "kv_entries",
+		Description: "The keys of a KV bucket and their current values",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "bucket", Require: plugin.Required},
+				{Name: "key", Require: plugin.Optional},
+				{Name: "key_filter", Require: plugin.Optional},
+				{Name: "include_deleted", Require: plugin.Optional},
+			},
+			Hydrate: listKVEntries,
+		},
+		Columns: []*plugin.Column{
+			{Name: "bucket", Type: proto.ColumnType_STRING, Transform: transform.FromField("Bucket")},
+			{Name: "key", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key")},
+			{Name: "key_filter", Type: proto.ColumnType_STRING, Transform: transform.FromField("KeyFilter")},
+			{Name: "value", Type: proto.ColumnType_STRING, Transform: transform.FromField("Value")},
+			{Name: "value_json", Type: proto.ColumnType_JSON, Transform: transform.FromField("ValueJSON")},
+			{Name: "revision", Type: proto.ColumnType_INT, Transform: transform.FromField("Revision")},
+			{Name: "created", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Created")},
+			{Name: "delta", Type: proto.ColumnType_INT, Transform: transform.FromField("Delta")},
+			{Name: "operation", Type: proto.ColumnType_STRING, Transform: transform.FromField("Operation")},
+			{Name: "include_deleted", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IncludeDeleted")},
+		},
+	}
+}
+
+// KVEntry is the latest revision of a key. Value is only set when it is valid
+// UTF-8 and ValueJSON when it is valid JSON. Delta is how many newer keys the
+// bucket holds, as reported by a KV watcher.
+type KVEntry struct {
+	Bucket         string          `json:"bucket"`
+	Key            string          `json:"key"`
+	KeyFilter      string          `json:"key_filter"`
+	Value          string          `json:"value"`
+	ValueJSON      json.RawMessage `json:"value_json"`
+	Revision       uint64          `json:"revision"`
+	Created        time.Time       `json:"created"`
+	Delta          uint64          `json:"delta"`
+	Operation      string          `json:"operation"`
+	IncludeDeleted bool            `json:"include_deleted"`
+}
+
+// kvStream is the stream backing bucket.
+func kvStream(bucket string) string {
+	return "KV_" + bucket
+}
+
+// kvSubject is the subject keys matching key are stored on in bucket.
+func kvSubject(bucket, key string) string {
+	return "$KV." + bucket + "." + key
+}
+
+// kvOperation returns whether msg is a put, delete or purge of its key.
+func kvOperation(msg *nats.Msg) string {
+	switch msg.Header.Get(kvOperationHeader) {
+	case "DEL":
+		return "delete"
+	case "PURGE":
+		return "purge"
+	default:
+		return "put"
+	}
+}
+
+// listKVEntries reads the last message of every key in the bucket, the way a
+// KV watcher does.
+func listKVEntries(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	js, err := getJetStream(d)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := d.KeyColumnQuals["bucket"].GetStringValue()
+	filter := d.KeyColumnQuals["key_filter"].GetStringValue()
+
+	includeDeleted := false
+	if q := d.KeyColumnQuals["include_deleted"]; q != nil {
+		includeDeleted = q.GetBoolValue()
+	}
+
+	keys, ok := narrowSubject(d, "key", "key_filter")
+	if !ok {
+		return nil, nil
+	}
+	if keys == "" {
+		keys = ">"
+	}
+
+	if _, err := js.KeyValue(bucket); err != nil {
+		return nil, bucketError(bucket, err)
+	}
+
+	prefix := kvSubject(bucket, "")
+	err = eachMessage(ctx, js, kvStream(bucket), kvSubject(bucket, keys), []nats.SubOpt{nats.DeliverLastPerSubject()}, func(msg *nats.Msg, meta *nats.MsgMetadata) bool {
+		op := kvOperation(msg)
+		if op != "put" && !includeDeleted {
+			return true
+		}
+
+		entry := KVEntry{
+			Bucket:         bucket,
+			Key:            msg.Subject[len(prefix):],
+			KeyFilter:      filter,
+			Revision:       meta.Sequence.Stream,
+			Created:        meta.Timestamp,
+			Delta:          meta.NumPending,
+			Operation:      op,
+			IncludeDeleted: includeDeleted,
+		}
+		entry.Value, entry.ValueJSON = decodePayload(msg.Data)
+		d.StreamListItem(ctx, entry)
+
+		return d.QueryStatus.RowsRemaining(ctx) > 0
+	})
+	if err != nil {
+		return nil, bucketError(bucket, err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,146 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+var kvEntriesTestColumns = []string{"bucket", "key", "key_filter", "value", "value_json", "revision", "created", "delta", "operation", "include_deleted"}
+
+// createBucket creates a KV bucket keeping history revisions per key, and
+// removes it when the test ends.
+func createBucket(t *testing.T, name string, history uint8) nats.KeyValue {
+	t.Helper()
+
+	nc, err := nats.Connect(testServer.ClientURL(), nats.UserInfo("app", "app"))
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatalf("jetstream failed: %v", err)
+	}
+
+	kv, err := js.CreateKeyValue(&nats.KeyValueConfig{Bucket: name, History: history, Storage: nats.MemoryStorage})
+	if err != nil {
+		t.Fatalf("create bucket failed: %v", err)
+	}
+	t.Cleanup(func() { js.DeleteKeyValue(name) })
+
+	return kv
+}
+
+func TestKVEntriesList(t *testing.T) {
+	rows := query(t, "kv_entries", kvEntriesTestColumns, map[string]string{"bucket": "config"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "bucket", "config")
+	assertString(t, rows[0], "key", "region")
+	assertString(t, rows[0], "value", "eu")
+	assertString(t, rows[0], "operation", "put")
+	assertInt(t, rows[0], "revision", 1)
+	if rows[0]["created"].GetTimestampValue() == nil {
+		t.Errorf("expected created to be set")
+	}
+}
+
+func TestKVEntriesFilter(t *testing.T) {
+	kv := createBucket(t, "entries", 1)
+	for key, value := range map[string]string{"app.a": `{"on":true}`, "app.b": "plain", "db.a": "x"} {
+		if _, err := kv.PutString(key, value); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+	if _, err := kv.PutString("app.a", `{"on":false}`); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+
+	rows := query(t, "kv_entries", kvEntriesTestColumns, map[string]string{"bucket": "entries"})
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	r := requireRow(t, rows, "key", "app.a")
+	assertInt(t, r, "revision", 4)
+	if v := string(r["value_json"].GetJsonValue()); v != `{"on":false}` {
+		t.Errorf("expected value_json to be the latest value, got %q", v)
+	}
+
+	r = requireRow(t, rows, "key", "app.b")
+	assertString(t, r, "value", "plain")
+	if v := string(r["value_json"].GetJsonValue()); v != "" && v != "null" {
+		t.Errorf("expected no value_json, got %q", v)
+	}
+
+	rows = query(t, "kv_entries", kvEntriesTestColumns, map[string]string{"bucket": "entries", "key_filter": "app.*"})
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	assertString(t, rows[0], "key_filter", "app.*")
+
+	rows = query(t, "kv_entries", kvEntriesTestColumns, map[string]string{"bucket": "entries", "key": "db.a"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertString(t, rows[0], "value", "x")
+
+	rows = query(t, "kv_entries", kvEntriesTestColumns, map[string]string{"bucket": "entries", "key": "db.a", "key_filter": "app.>"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
+
+func TestKVEntriesDeleted(t *testing.T) {
+	kv := createBucket(t, "deleted", 1)
+	for _, key := range []string{"kept", "deleted", "purged"} {
+		if _, err := kv.PutString(key, "value"); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+	if err := kv.Delete("deleted"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := kv.Purge("purged"); err != nil {
+		t.Fatalf("purge failed: %v", err)
+	}
+
+	rows := query(t, "kv_entries", kvEntriesTestColumns, map[string]string{"bucket": "deleted"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertString(t, rows[0], "key", "kept")
+
+	rows = queryQuals(t, "kv_entries", kvEntriesTestColumns, qual("bucket", "=", stringValue("deleted")), qual("include_deleted", "=", boolValue(true)))
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	assertString(t, requireRow(t, rows, "key", "deleted"), "operation", "delete")
+	assertString(t, requireRow(t, rows, "key", "purged"), "operation", "purge")
+	assertBool(t, rows[0], "include_deleted", true)
+}
+
+func TestKVEntriesLimit(t *testing.T) {
+	kv := createBucket(t, "limited", 1)
+	for _, key := range []string{"a", "b", "c"} {
+		if _, err := kv.PutString(key, "value"); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+
+	rows := queryLimit(t, "kv_entries", kvEntriesTestColumns, 2, qual("bucket", "=", stringValue("limited")))
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+}
+
+func TestKVEntriesMissing(t *testing.T) {
+	rows := query(t, "kv_entries", kvEntriesTestColumns, map[string]string{"bucket": "missing"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
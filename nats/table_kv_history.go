@@ -0,0 +1,92 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func kvHistory() *plugin.Table {
+	return &plugin.Table{
+		Name:        "kv_history",
+		Description: "Every retained revision of a key in a KV bucket",
+		List: &plugin.ListConfig{
+			KeyColumns: plugin.AllColumns([]string{"bucket", "key"}),
+			Hydrate:    listKVHistory,
+		},
+		Columns: []*plugin.Column{
+			{Name: "bucket", Type: proto.ColumnType_STRING, Transform: transform.FromField("Bucket")},
+			{Name: "key", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key")},
+			{Name: "revision", Type: proto.ColumnType_INT, Transform: transform.FromField("Revision")},
+			{Name: "value", Type: proto.ColumnType_STRING, Transform: transform.FromField("Value")},
+			{Name: "value_json", Type: proto.ColumnType_JSON, Transform: transform.FromField("ValueJSON")},
+			{Name: "created", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Created")},
+			{Name: "operation", Type: proto.ColumnType_STRING, Transform: transform.FromField("Operation")},
+			{Name: "stream_seq", Type: proto.ColumnType_INT, Transform: transform.FromField("StreamSeq")},
+			{Name: "headers", Type: proto.ColumnType_JSON, Transform: transform.FromField("Headers")},
+		},
+	}
+}
+
+// KVRevision is a single revision of a key, with the headers of the message
+// storing it. The revision of a key is the sequence of that message in the
+// bucket stream.
+type KVRevision struct {
+	Bucket    string          `json:"bucket"`
+	Key       string          `json:"key"`
+	Revision  uint64          `json:"revision"`
+	Value     string          `json:"value"`
+	ValueJSON json.RawMessage `json:"value_json"`
+	Created   time.Time       `json:"created"`
+	Operation string          `json:"operation"`
+	StreamSeq uint64          `json:"stream_seq"`
+	Headers   nats.Header     `json:"headers"`
+}
+
+func listKVHistory(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	js, err := getJetStream(d)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := d.KeyColumnQuals["bucket"].GetStringValue()
+	key := d.KeyColumnQuals["key"].GetStringValue()
+
+	// Keys cannot hold wildcards, so one in the qual would only select other
+	// keys' revisions for Postgres to discard.
+	if strings.ContainsAny(key, "*>") {
+		return nil, nil
+	}
+
+	if _, err := js.KeyValue(bucket); err != nil {
+		return nil, bucketError(bucket, err)
+	}
+
+	prefix := kvSubject(bucket, "")
+	err = eachMessage(ctx, js, kvStream(bucket), kvSubject(bucket, key), []nats.SubOpt{nats.DeliverAll()}, func(msg *nats.Msg, meta *nats.MsgMetadata) bool {
+		revision := KVRevision{
+			Bucket:    bucket,
+			Key:       msg.Subject[len(prefix):],
+			Revision:  meta.Sequence.Stream,
+			Created:   meta.Timestamp,
+			Operation: kvOperation(msg),
+			StreamSeq: meta.Sequence.Stream,
+			Headers:   msg.Header,
+		}
+		revision.Value, revision.ValueJSON = decodePayload(msg.Data)
+		d.StreamListItem(ctx, revision)
+
+		return d.QueryStatus.RowsRemaining(ctx) > 0
+	})
+	if err != nil {
+		return nil, bucketError(bucket, err)
+	}
+
+	return nil, nil
+}
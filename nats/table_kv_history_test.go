@@ -0,0 +1,62 @@
+package nats
+
+import "testing"
+
+var kvHistoryTestColumns = []string{"bucket", "key", "revision", "value", "value_json", "created", "operation", "stream_seq", "headers"}
+
+func TestKVHistoryList(t *testing.T) {
+	kv := createBucket(t, "history", 10)
+	for _, value := range []string{"v1", `{"v":2}`} {
+		if _, err := kv.PutString("app.setting", value); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+	if _, err := kv.PutString("other", "x"); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+	if err := kv.Delete("app.setting"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	rows := query(t, "kv_history", kvHistoryTestColumns, map[string]string{"bucket": "history", "key": "app.setting"})
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	r := requireIntRow(t, rows, "revision", 1)
+	assertString(t, r, "key", "app.setting")
+	assertInt(t, r, "revision", 1)
+	assertString(t, r, "value", "v1")
+	assertString(t, r, "operation", "put")
+	if r["created"].GetTimestampValue() == nil {
+		t.Errorf("expected created to be set")
+	}
+
+	r = requireIntRow(t, rows, "revision", 2)
+	if v := string(r["value_json"].GetJsonValue()); v != `{"v":2}` {
+		t.Errorf("expected value_json to be set, got %q", v)
+	}
+
+	r = requireIntRow(t, rows, "revision", 4)
+	assertString(t, r, "operation", "delete")
+	if v := string(r["headers"].GetJsonValue()); v != `{"KV-Operation":["DEL"]}` {
+		t.Errorf("expected the delete header, got %q", v)
+	}
+
+	rows = query(t, "kv_history", kvHistoryTestColumns, map[string]string{"bucket": "history", "key": "app.*"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows for a wildcard key, got %d", len(rows))
+	}
+}
+
+func TestKVHistoryMissing(t *testing.T) {
+	rows := query(t, "kv_history", kvHistoryTestColumns, map[string]string{"bucket": "config", "key": "missing"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+
+	rows = query(t, "kv_history", kvHistoryTestColumns, map[string]string{"bucket": "missing", "key": "region"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
@@ -54,17 +54,7 @@ func formatUpdatedTime(created, updated time.Time) time.Time {
 }
 
 func listKVInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
-	if err != nil {
-		return nil, err
-	}
-
-	nc, err := config.Connect()
-	if err != nil {
-		return nil, err
-	}
-
-	manager, err := jsm.New(nc)
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +73,7 @@ func listKVInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 	for _, k := range streams {
 		info, err := k.LatestInformation()
 		if err != nil {
-			return nil, err
+			return nil, streamError(k.Name(), err)
 		}
 
 		updated := formatUpdatedTime(info.Created, info.State.LastTime)
@@ -103,17 +93,7 @@ func listKVInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 }
 
 func getKVInfo(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
-	if err != nil {
-		return nil, err
-	}
-
-	nc, err := config.Connect()
-	if err != nil {
-		return nil, err
-	}
-
-	manager, err := jsm.New(nc)
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
@@ -126,12 +106,12 @@ func getKVInfo(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 
 	str, err := manager.LoadStream(name)
 	if err != nil {
-		return nil, err
+		return nil, streamError(name, err)
 	}
 
 	info, err := str.LatestInformation()
 	if err != nil {
-		return nil, err
+		return nil, streamError(name, err)
 	}
 
 	updated := formatUpdatedTime(info.Created, info.State.LastTime)
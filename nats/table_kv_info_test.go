@@ -0,0 +1,33 @@
+package nats
+
+import "testing"
+
+var kvInfoColumns = []string{"bucket", "description", "values"}
+
+func TestKVInfoList(t *testing.T) {
+	rows := query(t, "kv_info", kvInfoColumns, nil)
+	if len(rows) != 1 {
+		t.Fatalf("expected only the KV bucket to be listed, got %d rows", len(rows))
+	}
+
+	assertString(t, rows[0], "bucket", "config")
+	assertString(t, rows[0], "description", "test config")
+	assertInt(t, rows[0], "values", 1)
+}
+
+func TestKVInfoGet(t *testing.T) {
+	rows := query(t, "kv_info", kvInfoColumns, map[string]string{"bucket": "config"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "bucket", "config")
+	assertInt(t, rows[0], "values", 1)
+}
+
+func TestKVInfoGetMissing(t *testing.T) {
+	rows := query(t, "kv_info", kvInfoColumns, map[string]string{"bucket": "missing"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
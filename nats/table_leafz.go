@@ -0,0 +1,125 @@
+package nats
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func leafzInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "leafz_info",
+		Description: "The leafnode connections of every monitored server",
+		List: &plugin.ListConfig{
+			KeyColumns: append(serverKeyColumns(), plugin.OptionalColumns([]string{"account", "include_subscriptions"})...),
+			Hydrate:    listLeafzInfos,
+		},
+		Columns: []*plugin.Column{
+			{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerID")},
+			{Name: "server_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerName")},
+			{Name: "name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name")},
+			{Name: "is_spoke", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IsSpoke")},
+			{Name: "account", Type: proto.ColumnType_STRING, Transform: transform.FromField("Account")},
+			{Name: "ip", Type: proto.ColumnType_STRING, Transform: transform.FromField("IP")},
+			{Name: "port", Type: proto.ColumnType_INT, Transform: transform.FromField("Port")},
+			{Name: "rtt", Type: proto.ColumnType_STRING, Transform: transform.FromField("RTT")},
+			{Name: "in_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("InMsgs")},
+			{Name: "out_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("OutMsgs")},
+			{Name: "in_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("InBytes")},
+			{Name: "out_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("OutBytes")},
+			{Name: "subscriptions", Type: proto.ColumnType_INT, Transform: transform.FromField("NumSubs")},
+			{Name: "include_subscriptions", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IncludeSubscriptions")},
+			{Name: "subscriptions_list", Type: proto.ColumnType_JSON, Transform: transform.FromField("Subs")},
+			{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		},
+	}
+}
+
+// leafz is server.Leafz with the leafnode fields of newer servers.
+type leafz struct {
+	ID    string      `json:"server_id"`
+	Leafs []*leafInfo `json:"leafs"`
+}
+
+// leafInfo is server.LeafInfo plus the remote name and spoke flag, which
+// servers before 2.10 do not report.
+type leafInfo struct {
+	server.LeafInfo
+	Name    string `json:"name"`
+	IsSpoke bool   `json:"is_spoke"`
+}
+
+// LeafzInfo is a single leafnode connection of a server, or the error fetching
+// the leafnodes of a server. The subscription list is only filled in when the
+// query sets include_subscriptions.
+type LeafzInfo struct {
+	leafInfo
+	ServerID             string `json:"server_id"`
+	ServerName           string `json:"server_name"`
+	IncludeSubscriptions bool   `json:"include_subscriptions"`
+	SourceURL            string `json:"source_url"`
+	Error                string `json:"error"`
+}
+
+func listLeafzInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	monitor, err := getMonitor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := server.LeafzOptions{}
+	if q := d.KeyColumnQuals["include_subscriptions"]; q != nil {
+		opts.Subscriptions = q.GetBoolValue()
+	}
+	if q := d.KeyColumnQuals["account"]; q != nil {
+		opts.Account = q.GetStringValue()
+	}
+
+	query := url.Values{}
+	if opts.Subscriptions {
+		query.Set("subs", "true")
+	}
+	if opts.Account != "" {
+		query.Set("acc", opts.Account)
+	}
+
+	results, err := fetchServers[leafz](ctx, monitor, monitoringRequest{
+		Endpoint: "leafz",
+		Query:    query,
+		Options:  server.LeafzEventOptions{LeafzOptions: opts},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	servers := lookupServers(ctx, monitor, results)
+
+	for _, r := range results {
+		name := servers.name(r.Value.ID)
+		if !matchesServerQuals(d, r.Value.ID, name) {
+			continue
+		}
+
+		if r.Err != nil {
+			d.StreamListItem(ctx, LeafzInfo{SourceURL: r.URL, Error: errorString(r.Err)})
+			continue
+		}
+
+		for _, leaf := range r.Value.Leafs {
+			d.StreamListItem(ctx, LeafzInfo{
+				leafInfo:             *leaf,
+				ServerID:             r.Value.ID,
+				ServerName:           name,
+				IncludeSubscriptions: opts.Subscriptions,
+				SourceURL:            r.URL,
+			})
+		}
+	}
+
+	return nil, nil
+}
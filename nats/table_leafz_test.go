@@ -0,0 +1,103 @@
+package nats
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+var leafzTestColumns = []string{"server_id", "server_name", "account", "ip", "subscriptions", "include_subscriptions", "subscriptions_list", "source_url", "error"}
+
+const testHubConfig = `
+server_name: hub
+listen: 127.0.0.1:-1
+http: 127.0.0.1:-1
+leafnodes {
+  listen: 127.0.0.1:%d
+}
+`
+
+const testSpokeConfig = `
+server_name: spoke
+listen: 127.0.0.1:-1
+http: 127.0.0.1:-1
+leafnodes {
+  remotes: [{url: "nats-leaf://127.0.0.1:%d"}]
+}
+`
+
+// runLeafnode starts a hub and a spoke server connected by a leafnode, with a
+// subscription on leafz.test made on the spoke.
+func runLeafnode(t *testing.T) (*server.Server, *server.Server) {
+	t.Helper()
+
+	port := freePort(t)
+	hub := runServer(t, fmt.Sprintf(testHubConfig, port))
+	spoke := runServer(t, fmt.Sprintf(testSpokeConfig, port))
+
+	waitFor(t, "leafnode", func() bool {
+		return hub.NumLeafNodes() == 1 && spoke.NumLeafNodes() == 1
+	})
+
+	nc, err := nats.Connect(spoke.ClientURL())
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(nc.Close)
+	if _, err := nc.SubscribeSync("leafz.test"); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	if err := nc.Flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	return hub, spoke
+}
+
+func TestLeafzInfoList(t *testing.T) {
+	hub, spoke := runLeafnode(t)
+
+	config := fmt.Sprintf("monitoring_urls = [%q, %q]", monitoringURL(hub), monitoringURL(spoke))
+	rows := queryConfig(t, config, "leafz_info", leafzTestColumns)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	r := requireRow(t, rows, "server_name", "hub")
+	assertString(t, r, "server_id", hub.ID())
+	assertString(t, r, "account", "$G")
+	assertString(t, r, "ip", "127.0.0.1")
+	assertString(t, r, "source_url", monitoringURL(hub))
+	assertString(t, r, "error", "")
+	if subs := string(r["subscriptions_list"].GetJsonValue()); subs != "" && subs != "null" {
+		t.Errorf("expected no subscriptions list, got %s", r["subscriptions_list"].GetJsonValue())
+	}
+
+	requireRow(t, rows, "server_name", "spoke")
+}
+
+func TestLeafzInfoSubscriptions(t *testing.T) {
+	hub, _ := runLeafnode(t)
+
+	config := fmt.Sprintf("monitoring_url = %q", monitoringURL(hub))
+	var rows []testRow
+	waitFor(t, "leafnode subscription", func() bool {
+		rows = queryConfig(t, config, "leafz_info", leafzTestColumns, qual("include_subscriptions", "=", boolValue(true)))
+		return len(rows) == 1 && rows[0]["subscriptions"].GetIntValue() > 0
+	})
+
+	assertBool(t, rows[0], "include_subscriptions", true)
+	if subs := string(rows[0]["subscriptions_list"].GetJsonValue()); !strings.Contains(subs, `"leafz.test"`) {
+		t.Errorf("expected the leafz.test subscription, got %s", subs)
+	}
+}
+
+func TestLeafzInfoNoLeafnodes(t *testing.T) {
+	rows := query(t, "leafz_info", leafzTestColumns, nil)
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
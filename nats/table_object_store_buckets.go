@@ -0,0 +1,189 @@
+package nats
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func objectStoreBuckets() *plugin.Table {
+	return &plugin.Table{
+		Name:        "object_store_buckets",
+		Description: "Object store buckets",
+		List: &plugin.ListConfig{
+			Hydrate: listObjectStoreBuckets,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("bucket"),
+			Hydrate:    getObjectStoreBucket,
+		},
+		Columns: []*plugin.Column{
+			{Name: "bucket", Type: proto.ColumnType_STRING, Transform: transform.FromField("Bucket")},
+			{Name: "description", Type: proto.ColumnType_STRING, Transform: transform.FromField("Description")},
+			{Name: "ttl", Type: proto.ColumnType_INT, Transform: transform.FromField("TTL")},
+			{Name: "storage", Type: proto.ColumnType_STRING, Transform: transform.FromField("Storage")},
+			{Name: "replicas", Type: proto.ColumnType_INT, Transform: transform.FromField("Replicas")},
+			{Name: "sealed", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Sealed")},
+			{Name: "size", Type: proto.ColumnType_INT, Transform: transform.FromField("Size")},
+			{Name: "objects", Type: proto.ColumnType_INT, Transform: transform.FromField("Objects")},
+			{Name: "created", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Created")},
+			{Name: "stream", Type: proto.ColumnType_STRING, Transform: transform.FromField("Stream")},
+		},
+	}
+}
+
+// ObjectStore is an object store bucket. Size includes the object metadata,
+// and Objects does not count deleted objects.
+type ObjectStore struct {
+	Bucket      string          `json:"bucket"`
+	Description string          `json:"description"`
+	TTL         time.Duration   `json:"ttl"`
+	Storage     api.StorageType `json:"storage"`
+	Replicas    int             `json:"replicas"`
+	Sealed      bool            `json:"sealed"`
+	Size        uint64          `json:"size"`
+	Objects     int64           `json:"objects"`
+	Created     time.Time       `json:"created"`
+	Stream      string          `json:"stream"`
+}
+
+// objStream is the stream backing bucket.
+func objStream(bucket string) string {
+	return "OBJ_" + bucket
+}
+
+// objMetaSubject is the subject the metadata of the named object in bucket is
+// stored on.
+func objMetaSubject(bucket, name string) string {
+	return "$O." + bucket + ".M." + base64.URLEncoding.EncodeToString([]byte(name))
+}
+
+// eachObject calls cb with the latest metadata of every object in bucket,
+// deleted objects included, until it returns false. When names is not nil
+// only the metadata of those objects is read.
+func eachObject(ctx context.Context, js nats.JetStreamContext, bucket string, names []string, cb func(*nats.ObjectInfo) bool) error {
+	subjects := []string{"$O." + bucket + ".M.>"}
+	if names != nil {
+		subjects = nil
+		for _, name := range names {
+			subjects = append(subjects, objMetaSubject(bucket, name))
+		}
+	}
+
+	more := true
+	for _, subject := range subjects {
+		err := eachMessage(ctx, js, objStream(bucket), subject, []nats.SubOpt{nats.DeliverLastPerSubject()}, func(msg *nats.Msg, meta *nats.MsgMetadata) bool {
+			var info nats.ObjectInfo
+			if err := json.Unmarshal(msg.Data, &info); err != nil {
+				return true
+			}
+			// Only the stored message knows when an object was last changed.
+			info.ModTime = meta.Timestamp
+
+			more = cb(&info)
+			return more
+		})
+		if err != nil || !more {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func objectStore(ctx context.Context, js nats.JetStreamContext, s *jsm.Stream) (*ObjectStore, error) {
+	info, err := s.LatestInformation()
+	if err != nil {
+		return nil, streamError(s.Name(), err)
+	}
+
+	bucket := strings.TrimPrefix(s.Name(), "OBJ_")
+
+	var objects int64
+	err = eachObject(ctx, js, bucket, nil, func(o *nats.ObjectInfo) bool {
+		if !o.Deleted {
+			objects++
+		}
+		return true
+	})
+	if err != nil {
+		return nil, bucketError(bucket, err)
+	}
+
+	return &ObjectStore{
+		Bucket:      bucket,
+		Description: s.Description(),
+		TTL:         s.MaxAge(),
+		Storage:     s.Storage(),
+		Replicas:    s.Replicas(),
+		Sealed:      s.Sealed(),
+		Size:        info.State.Bytes,
+		Objects:     objects,
+		Created:     info.Created,
+		Stream:      s.Name(),
+	}, nil
+}
+
+func listObjectStoreBuckets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	manager, err := getManager(d)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := getJetStream(d)
+	if err != nil {
+		return nil, err
+	}
+
+	var streams []*jsm.Stream
+
+	err = manager.EachStream(func(s *jsm.Stream) {
+		if s.IsObjectBucket() {
+			streams = append(streams, s)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range streams {
+		bucket, err := objectStore(ctx, js, s)
+		if err != nil {
+			return nil, err
+		}
+
+		d.StreamListItem(ctx, bucket)
+	}
+
+	return nil, nil
+}
+
+func getObjectStoreBucket(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	manager, err := getManager(d)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := getJetStream(d)
+	if err != nil {
+		return nil, err
+	}
+
+	name := objStream(d.KeyColumnQuals["bucket"].GetStringValue())
+
+	s, err := manager.LoadStream(name)
+	if err != nil {
+		return nil, streamError(name, err)
+	}
+
+	return objectStore(ctx, js, s)
+}
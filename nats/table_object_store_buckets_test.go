@@ -0,0 +1,82 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+var objectStoreBucketTestColumns = []string{"bucket", "description", "ttl", "storage", "replicas", "sealed", "size", "objects", "created", "stream"}
+
+// createObjectStore creates a memory object store bucket and removes it when
+// the test ends.
+func createObjectStore(t *testing.T, name string) (nats.JetStreamContext, nats.ObjectStore) {
+	t.Helper()
+
+	nc, err := nats.Connect(testServer.ClientURL(), nats.UserInfo("app", "app"))
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatalf("jetstream failed: %v", err)
+	}
+
+	obs, err := js.CreateObjectStore(&nats.ObjectStoreConfig{Bucket: name, Storage: nats.MemoryStorage})
+	if err != nil {
+		t.Fatalf("create object store failed: %v", err)
+	}
+	t.Cleanup(func() { js.DeleteObjectStore(name) })
+
+	return js, obs
+}
+
+func TestObjectStoreBucketsList(t *testing.T) {
+	rows := query(t, "object_store_buckets", objectStoreBucketTestColumns, nil)
+	if len(rows) != 1 {
+		t.Fatalf("expected only the object store to be listed, got %d rows", len(rows))
+	}
+
+	assertString(t, rows[0], "bucket", "models")
+	assertString(t, rows[0], "description", "test models")
+	assertString(t, rows[0], "storage", "File")
+	assertInt(t, rows[0], "replicas", 1)
+	assertInt(t, rows[0], "objects", 1)
+	assertString(t, rows[0], "stream", "OBJ_models")
+	if rows[0]["size"].GetIntValue() == 0 {
+		t.Errorf("expected size to be set")
+	}
+	if rows[0]["created"].GetTimestampValue() == nil {
+		t.Errorf("expected created to be set")
+	}
+}
+
+func TestObjectStoreBucketsGet(t *testing.T) {
+	_, obs := createObjectStore(t, "artifacts")
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := obs.PutString(name, "data"); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+	if err := obs.Delete("b"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	rows := query(t, "object_store_buckets", objectStoreBucketTestColumns, map[string]string{"bucket": "artifacts"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "bucket", "artifacts")
+	assertString(t, rows[0], "storage", "Memory")
+	assertInt(t, rows[0], "objects", 2)
+}
+
+func TestObjectStoreBucketsGetMissing(t *testing.T) {
+	rows := query(t, "object_store_buckets", objectStoreBucketTestColumns, map[string]string{"bucket": "missing"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
@@ -0,0 +1,128 @@
+package nats
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func objectStoreObjects() *plugin.Table {
+	return &plugin.Table{
+		Name:        "object_store_objects",
+		Description: "The objects stored in an object store bucket",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "bucket", Require: plugin.Required},
+				{Name: "name", Require: plugin.Optional},
+				{Name: "verify", Require: plugin.Optional},
+			},
+			Hydrate: listObjectStoreObjects,
+		},
+		Columns: []*plugin.Column{
+			{Name: "bucket", Type: proto.ColumnType_STRING, Transform: transform.FromField("Bucket")},
+			{Name: "name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name")},
+			{Name: "description", Type: proto.ColumnType_STRING, Transform: transform.FromField("Description")},
+			{Name: "size", Type: proto.ColumnType_INT, Transform: transform.FromField("Size")},
+			{Name: "chunks", Type: proto.ColumnType_INT, Transform: transform.FromField("Chunks")},
+			{Name: "nuid", Type: proto.ColumnType_STRING, Transform: transform.FromField("NUID")},
+			{Name: "digest", Type: proto.ColumnType_STRING, Transform: transform.FromField("Digest")},
+			{Name: "modified", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("ModTime")},
+			{Name: "deleted", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Deleted")},
+			{Name: "headers", Type: proto.ColumnType_JSON, Transform: transform.FromField("Headers")},
+			{Name: "link_bucket", Type: proto.ColumnType_STRING, Transform: transform.FromField("Opts.Link.Bucket")},
+			{Name: "link_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Opts.Link.Name")},
+			{Name: "verify", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Verify")},
+			{Name: "digest_valid", Type: proto.ColumnType_BOOL, Transform: transform.FromField("DigestValid")},
+		},
+	}
+}
+
+// ObjectStoreObject is the metadata of an object. When the query sets verify,
+// DigestValid tells whether the digest of the stored chunks matches the
+// metadata. It stays unset for deleted objects and links, which have no
+// chunks.
+type ObjectStoreObject struct {
+	nats.ObjectInfo
+	Verify      bool  `json:"verify"`
+	DigestValid *bool `json:"digest_valid"`
+}
+
+// verifyObject reads the chunks of info and compares their digest to the one
+// recorded in its metadata.
+func verifyObject(ctx context.Context, js nats.JetStreamContext, info *nats.ObjectInfo) (bool, error) {
+	h := sha256.New()
+
+	subject := "$O." + info.Bucket + ".C." + info.NUID
+	err := eachMessage(ctx, js, objStream(info.Bucket), subject, []nats.SubOpt{nats.DeliverAll()}, func(msg *nats.Msg, _ *nats.MsgMetadata) bool {
+		h.Write(msg.Data)
+		return true
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return nats.GetObjectDigestValue(h) == info.Digest, nil
+}
+
+func listObjectStoreObjects(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	js, err := getJetStream(d)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := GetConfig(d.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := d.KeyColumnQuals["bucket"].GetStringValue()
+	names := qualStrings(d, "name")
+
+	verify := false
+	if q := d.KeyColumnQuals["verify"]; q != nil {
+		verify = q.GetBoolValue()
+	}
+
+	if _, err := js.ObjectStore(bucket); err != nil {
+		return nil, bucketError(bucket, err)
+	}
+
+	var objects []ObjectStoreObject
+	err = eachObject(ctx, js, bucket, names, func(info *nats.ObjectInfo) bool {
+		objects = append(objects, ObjectStoreObject{ObjectInfo: *info, Verify: verify})
+		return true
+	})
+	if err != nil {
+		return nil, bucketError(bucket, err)
+	}
+
+	// Verifying reads every chunk of an object, so objects are verified in
+	// parallel.
+	load := func(o ObjectStoreObject) ([]ObjectStoreObject, error) {
+		if !verify || o.Deleted || o.Opts != nil && o.Opts.Link != nil {
+			return []ObjectStoreObject{o}, nil
+		}
+
+		valid, err := verifyObject(ctx, js, &o.ObjectInfo)
+		if err != nil {
+			return nil, bucketError(bucket, err)
+		}
+		o.DigestValid = &valid
+
+		return []ObjectStoreObject{o}, nil
+	}
+
+	err = fanOut(ctx, config.maxConcurrency(), objects, load, func(o ObjectStoreObject) bool {
+		d.StreamListItem(ctx, o)
+		return d.QueryStatus.RowsRemaining(ctx) > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
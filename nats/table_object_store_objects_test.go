@@ -0,0 +1,123 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+)
+
+var objectStoreObjectTestColumns = []string{"bucket", "name", "description", "size", "chunks", "nuid", "digest", "modified", "deleted", "headers", "link_bucket", "link_name", "verify", "digest_valid"}
+
+func TestObjectStoreObjectsList(t *testing.T) {
+	rows := query(t, "object_store_objects", objectStoreObjectTestColumns, map[string]string{"bucket": "models"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "bucket", "models")
+	assertString(t, rows[0], "name", "model.bin")
+	assertInt(t, rows[0], "size", 7)
+	assertInt(t, rows[0], "chunks", 1)
+	assertBool(t, rows[0], "deleted", false)
+	if rows[0]["nuid"].GetStringValue() == "" || rows[0]["digest"].GetStringValue() == "" {
+		t.Errorf("expected nuid and digest to be set")
+	}
+	if rows[0]["modified"].GetTimestampValue() == nil {
+		t.Errorf("expected modified to be set")
+	}
+	if _, null := rows[0]["digest_valid"].GetValue().(*proto.Column_NullValue); !null {
+		t.Errorf("expected digest_valid to be unset without verify")
+	}
+}
+
+func TestObjectStoreObjectsMetadata(t *testing.T) {
+	_, obs := createObjectStore(t, "metadata")
+
+	info, err := obs.Put(&nats.ObjectMeta{Name: "described", Description: "an object", Headers: nats.Header{"Owner": []string{"ml"}}}, nil)
+	if err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+	if _, err := obs.AddLink("linked", info); err != nil {
+		t.Fatalf("add link failed: %v", err)
+	}
+	if _, err := obs.PutString("removed", "data"); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+	if err := obs.Delete("removed"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	rows := query(t, "object_store_objects", objectStoreObjectTestColumns, map[string]string{"bucket": "metadata"})
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	r := requireRow(t, rows, "name", "described")
+	assertString(t, r, "description", "an object")
+	if v := string(r["headers"].GetJsonValue()); v != `{"Owner":["ml"]}` {
+		t.Errorf("expected headers to be set, got %q", v)
+	}
+
+	r = requireRow(t, rows, "name", "linked")
+	assertString(t, r, "link_bucket", "metadata")
+	assertString(t, r, "link_name", "described")
+
+	r = requireRow(t, rows, "name", "removed")
+	assertBool(t, r, "deleted", true)
+
+	rows = query(t, "object_store_objects", objectStoreObjectTestColumns, map[string]string{"bucket": "metadata", "name": "linked"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertString(t, rows[0], "name", "linked")
+
+	rows = queryQuals(t, "object_store_objects", objectStoreObjectTestColumns, qual("bucket", "=", stringValue("metadata")), qual("name", "=", listValue("described", "removed", "missing")))
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	requireRow(t, rows, "name", "described")
+	requireRow(t, rows, "name", "removed")
+}
+
+func TestObjectStoreObjectsVerify(t *testing.T) {
+	js, obs := createObjectStore(t, "verified")
+	for _, name := range []string{"intact", "corrupt"} {
+		if _, err := obs.PutString(name, "weights"); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+
+	info, err := obs.GetInfo("corrupt")
+	if err != nil {
+		t.Fatalf("get info failed: %v", err)
+	}
+	if _, err := js.Publish("$O.verified.C."+info.NUID, []byte("garbage")); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	rows := queryQuals(t, "object_store_objects", objectStoreObjectTestColumns, qual("bucket", "=", stringValue("verified")), qual("verify", "=", boolValue(true)))
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	r := requireRow(t, rows, "name", "intact")
+	assertBool(t, r, "verify", true)
+	assertBool(t, r, "digest_valid", true)
+	if _, null := r["digest_valid"].GetValue().(*proto.Column_NullValue); null {
+		t.Errorf("expected digest_valid to be set")
+	}
+
+	r = requireRow(t, rows, "name", "corrupt")
+	assertBool(t, r, "digest_valid", false)
+	if _, null := r["digest_valid"].GetValue().(*proto.Column_NullValue); null {
+		t.Errorf("expected digest_valid to be set")
+	}
+}
+
+func TestObjectStoreObjectsMissing(t *testing.T) {
+	rows := query(t, "object_store_objects", objectStoreObjectTestColumns, map[string]string{"bucket": "missing"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
@@ -0,0 +1,97 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func routezInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "routez_info",
+		Description: "The cluster routes of every monitored server",
+		List: &plugin.ListConfig{
+			KeyColumns: serverKeyColumns(),
+			Hydrate:    listRoutezInfos,
+		},
+		Columns: []*plugin.Column{
+			{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerID")},
+			{Name: "server_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerName")},
+			{Name: "rid", Type: proto.ColumnType_INT, Transform: transform.FromField("Rid")},
+			{Name: "remote_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("RemoteID")},
+			{Name: "remote_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("RemoteName")},
+			{Name: "did_solicit", Type: proto.ColumnType_BOOL, Transform: transform.FromField("DidSolicit")},
+			{Name: "is_configured", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IsConfigured")},
+			{Name: "ip", Type: proto.ColumnType_STRING, Transform: transform.FromField("IP")},
+			{Name: "port", Type: proto.ColumnType_INT, Transform: transform.FromField("Port")},
+			{Name: "start", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Start")},
+			{Name: "last_activity", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("LastActivity")},
+			{Name: "rtt", Type: proto.ColumnType_STRING, Transform: transform.FromField("RTT")},
+			{Name: "uptime", Type: proto.ColumnType_STRING, Transform: transform.FromField("Uptime")},
+			{Name: "idle", Type: proto.ColumnType_STRING, Transform: transform.FromField("Idle")},
+			{Name: "import", Type: proto.ColumnType_JSON, Transform: transform.FromField("Import")},
+			{Name: "export", Type: proto.ColumnType_JSON, Transform: transform.FromField("Export")},
+			{Name: "pending_size", Type: proto.ColumnType_INT, Transform: transform.FromField("Pending")},
+			{Name: "in_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("InMsgs")},
+			{Name: "out_msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("OutMsgs")},
+			{Name: "in_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("InBytes")},
+			{Name: "out_bytes", Type: proto.ColumnType_INT, Transform: transform.FromField("OutBytes")},
+			{Name: "subscriptions", Type: proto.ColumnType_INT, Transform: transform.FromField("NumSubs")},
+			{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		},
+	}
+}
+
+// RoutezInfo is a single route of a server, or the error fetching the routes
+// of a server. Routez only carries server IDs, so the names are looked up in
+// the varz of the monitored servers and are empty for unmonitored remotes.
+type RoutezInfo struct {
+	server.RouteInfo
+	ServerID   string `json:"server_id"`
+	ServerName string `json:"server_name"`
+	RemoteName string `json:"remote_name"`
+	SourceURL  string `json:"source_url"`
+	Error      string `json:"error"`
+}
+
+func listRoutezInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	monitor, err := getMonitor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := fetchServers[server.Routez](ctx, monitor, monitoringRequest{Endpoint: "routez"})
+	if err != nil {
+		return nil, err
+	}
+
+	servers := lookupServers(ctx, monitor, results)
+
+	for _, r := range results {
+		name := servers.name(r.Value.ID)
+		if !matchesServerQuals(d, r.Value.ID, name) {
+			continue
+		}
+
+		if r.Err != nil {
+			d.StreamListItem(ctx, RoutezInfo{SourceURL: r.URL, Error: errorString(r.Err)})
+			continue
+		}
+
+		for _, route := range r.Value.Routes {
+			d.StreamListItem(ctx, RoutezInfo{
+				RouteInfo:  *route,
+				ServerID:   r.Value.ID,
+				ServerName: name,
+				RemoteName: servers.name(route.RemoteID),
+				SourceURL:  r.URL,
+			})
+		}
+	}
+
+	return nil, nil
+}
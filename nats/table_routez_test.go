@@ -0,0 +1,79 @@
+package nats
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+var routezTestColumns = []string{"server_id", "server_name", "remote_id", "remote_name", "did_solicit", "is_configured", "source_url", "error"}
+
+const testClusterConfig = `
+server_name: %s
+listen: 127.0.0.1:-1
+http: 127.0.0.1:-1
+cluster {
+  name: test-cluster
+  listen: 127.0.0.1:-1
+  routes: [%s]
+}
+`
+
+// runCluster starts two servers that are routed to each other.
+func runCluster(t *testing.T) (*server.Server, *server.Server) {
+	t.Helper()
+
+	a := runServer(t, fmt.Sprintf(testClusterConfig, "server-a", ""))
+	b := runServer(t, fmt.Sprintf(testClusterConfig, "server-b", fmt.Sprintf("nats-route://%s", a.ClusterAddr())))
+
+	waitFor(t, "routes", func() bool {
+		return a.NumRoutes() == 1 && b.NumRoutes() == 1
+	})
+
+	return a, b
+}
+
+func TestRoutezInfoList(t *testing.T) {
+	a, b := runCluster(t)
+
+	config := fmt.Sprintf("monitoring_urls = [%q, %q]", monitoringURL(a), monitoringURL(b))
+	rows := queryConfig(t, config, "routez_info", routezTestColumns)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	r := requireRow(t, rows, "server_name", "server-a")
+	assertString(t, r, "server_id", a.ID())
+	assertString(t, r, "remote_id", b.ID())
+	assertString(t, r, "remote_name", "server-b")
+	assertBool(t, r, "did_solicit", false)
+	assertString(t, r, "source_url", monitoringURL(a))
+	assertString(t, r, "error", "")
+
+	r = requireRow(t, rows, "server_name", "server-b")
+	assertString(t, r, "remote_name", "server-a")
+	assertBool(t, r, "did_solicit", true)
+	assertBool(t, r, "is_configured", true)
+}
+
+func TestRoutezInfoSingleServer(t *testing.T) {
+	a, b := runCluster(t)
+
+	config := fmt.Sprintf("monitoring_url = %q", monitoringURL(b))
+	rows := queryConfig(t, config, "routez_info", routezTestColumns)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_name", "server-b")
+	assertString(t, rows[0], "remote_id", a.ID())
+	assertString(t, rows[0], "remote_name", "")
+}
+
+func TestRoutezInfoNoRoutes(t *testing.T) {
+	rows := query(t, "routez_info", routezTestColumns, nil)
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
@@ -52,17 +52,7 @@ func streamConfigs() *plugin.Table {
 }
 
 func listStreamConfigs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
-	if err != nil {
-		return nil, err
-	}
-
-	nc, err := config.Connect()
-	if err != nil {
-		return nil, err
-	}
-
-	manager, err := jsm.New(nc)
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
@@ -80,17 +70,7 @@ func listStreamConfigs(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 }
 
 func getStreamConfig(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
-	if err != nil {
-		return nil, err
-	}
-
-	nc, err := config.Connect()
-	if err != nil {
-		return nil, err
-	}
-
-	manager, err := jsm.New(nc)
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +79,7 @@ func getStreamConfig(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 
 	stream, err := manager.LoadStream(name)
 	if err != nil {
-		return nil, err
+		return nil, streamError(name, err)
 	}
 
 	return stream.Configuration(), nil
@@ -0,0 +1,25 @@
+package nats
+
+import "testing"
+
+var streamConfigColumns = []string{"name", "description", "subjects", "storage", "replicas", "max_msgs"}
+
+func TestStreamConfigsList(t *testing.T) {
+	rows := query(t, "stream_configs", streamConfigColumns, nil)
+
+	r := requireRow(t, rows, "name", "ORDERS")
+	assertString(t, r, "description", "test orders")
+	assertString(t, r, "storage", "File")
+	assertInt(t, r, "replicas", 1)
+	assertInt(t, r, "max_msgs", -1)
+}
+
+func TestStreamConfigsGet(t *testing.T) {
+	rows := query(t, "stream_configs", streamConfigColumns, map[string]string{"name": "ORDERS"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "name", "ORDERS")
+	assertString(t, rows[0], "description", "test orders")
+}
@@ -3,7 +3,6 @@ package nats
 import (
 	"context"
 
-	"github.com/nats-io/jsm.go"
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
@@ -38,17 +37,7 @@ func streamInfo() *plugin.Table {
 }
 
 func listStreamInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
-	if err != nil {
-		return nil, err
-	}
-
-	nc, err := config.Connect()
-	if err != nil {
-		return nil, err
-	}
-
-	manager, err := jsm.New(nc)
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
@@ -58,10 +47,12 @@ func listStreamInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		return nil, err
 	}
 
+	// Streams lists the streams with their info, which LatestInformation
+	// returns without another API request, so there is nothing to fan out.
 	for _, v := range streams {
 		info, err := v.LatestInformation()
 		if err != nil {
-			return nil, err
+			return nil, streamError(v.Name(), err)
 		}
 		d.StreamListItem(ctx, info)
 	}
@@ -71,27 +62,22 @@ func listStreamInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 }
 
 func getStreamInfo(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
+	manager, err := getManager(d)
 	if err != nil {
 		return nil, err
 	}
 
-	nc, err := config.Connect()
-	if err != nil {
-		return nil, err
-	}
+	name := d.KeyColumnQuals["name"].GetStringValue()
 
-	manager, err := jsm.New(nc)
+	stream, err := manager.LoadStream(name)
 	if err != nil {
-		return nil, err
+		return nil, streamError(name, err)
 	}
 
-	name := d.KeyColumnQuals["name"].GetStringValue()
-
-	stream, err := manager.LoadStream(name)
+	info, err := stream.LatestInformation()
 	if err != nil {
-		return nil, err
+		return nil, streamError(name, err)
 	}
 
-	return stream.LatestInformation()
+	return info, nil
 }
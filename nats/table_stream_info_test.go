@@ -0,0 +1,32 @@
+package nats
+
+import "testing"
+
+var streamInfoColumns = []string{"name", "msgs", "bytes", "first_seq", "last_seq", "consumers"}
+
+func TestStreamInfoList(t *testing.T) {
+	rows := query(t, "stream_info", streamInfoColumns, nil)
+
+	r := requireRow(t, rows, "name", "ORDERS")
+	assertInt(t, r, "msgs", 3)
+	assertInt(t, r, "first_seq", 1)
+	assertInt(t, r, "last_seq", 3)
+	assertInt(t, r, "consumers", 1)
+}
+
+func TestStreamInfoGet(t *testing.T) {
+	rows := query(t, "stream_info", streamInfoColumns, map[string]string{"name": "ORDERS"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "name", "ORDERS")
+	assertInt(t, rows[0], "msgs", 3)
+}
+
+func TestStreamInfoGetMissing(t *testing.T) {
+	rows := query(t, "stream_info", streamInfoColumns, map[string]string{"name": "MISSING"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
@@ -0,0 +1,168 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func streamMessages() *plugin.Table {
+	return &plugin.Table{
+		Name:        "stream_messages",
+		Description: "The messages stored in a stream",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "stream", Require: plugin.Required},
+				{Name: "seq", Require: plugin.Optional, Operators: rangeOperators},
+				{Name: "time", Require: plugin.Optional, Operators: rangeOperators},
+				{Name: "subject", Require: plugin.Optional},
+				{Name: "subject_filter", Require: plugin.Optional},
+			},
+			Hydrate: listStreamMessages,
+		},
+		Columns: []*plugin.Column{
+			{Name: "stream", Type: proto.ColumnType_STRING, Transform: transform.FromField("Stream")},
+			{Name: "seq", Type: proto.ColumnType_INT, Transform: transform.FromField("Seq")},
+			{Name: "subject", Type: proto.ColumnType_STRING, Transform: transform.FromField("Subject")},
+			{Name: "subject_filter", Type: proto.ColumnType_STRING, Transform: transform.FromField("SubjectFilter")},
+			{Name: "time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Time")},
+			{Name: "headers", Type: proto.ColumnType_JSON, Transform: transform.FromField("Headers")},
+			{Name: "size", Type: proto.ColumnType_INT, Transform: transform.FromField("Size")},
+			{Name: "payload", Type: proto.ColumnType_STRING, Transform: transform.FromField("Payload")},
+			{Name: "payload_json", Type: proto.ColumnType_JSON, Transform: transform.FromField("PayloadJSON")},
+		},
+	}
+}
+
+// StreamMessage is a single message stored in a stream. Payload is only set
+// when the message body is valid UTF-8 and PayloadJSON when it is valid JSON.
+type StreamMessage struct {
+	Stream        string          `json:"stream"`
+	Seq           uint64          `json:"seq"`
+	Subject       string          `json:"subject"`
+	SubjectFilter string          `json:"subject_filter"`
+	Time          time.Time       `json:"time"`
+	Headers       nats.Header     `json:"headers"`
+	Size          int             `json:"size"`
+	Payload       string          `json:"payload"`
+	PayloadJSON   json.RawMessage `json:"payload_json"`
+}
+
+func newStreamMessage(stream, filter, subject string, seq uint64, t time.Time, headers nats.Header, data []byte) StreamMessage {
+	msg := StreamMessage{
+		Stream:        stream,
+		Seq:           seq,
+		Subject:       subject,
+		SubjectFilter: filter,
+		Time:          t,
+		Headers:       headers,
+		Size:          len(data),
+	}
+	msg.Payload, msg.PayloadJSON = decodePayload(data)
+
+	return msg
+}
+
+// directGetHeaders removes the headers a direct get adds to describe the
+// message, so both ways of reading a message show the same headers.
+func directGetHeaders(headers nats.Header) nats.Header {
+	for _, h := range []string{nats.JSStream, nats.JSSequence, nats.JSTimeStamp, nats.JSSubject} {
+		headers.Del(h)
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+
+	return headers
+}
+
+// listStreamMessages reads a stream without leaving any state behind on the
+// server. A single sequence is read with a get, which is a direct get when the
+// stream allows it, and anything else with eachMessage.
+func listStreamMessages(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	js, err := getJetStream(d)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := d.KeyColumnQuals["stream"].GetStringValue()
+	filter := d.KeyColumnQuals["subject_filter"].GetStringValue()
+
+	consumerFilter, ok := narrowSubject(d, "subject", "subject_filter")
+	if !ok {
+		return nil, nil
+	}
+
+	first, last, ok := seqRange(d, "seq")
+	if !ok {
+		return nil, nil
+	}
+
+	info, err := js.StreamInfo(stream, nats.Context(ctx))
+	if err != nil {
+		return nil, streamError(stream, err)
+	}
+
+	if last > info.State.LastSeq {
+		last = info.State.LastSeq
+	}
+	if first > last || info.State.Msgs == 0 {
+		return nil, nil
+	}
+	from, to := timeRange(d, "time")
+
+	if first == last {
+		var opts []nats.JSOpt
+		if info.Config.AllowDirect {
+			opts = append(opts, nats.DirectGet())
+		}
+
+		msg, err := js.GetMsg(stream, first, append(opts, nats.Context(ctx))...)
+		if isNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, streamError(stream, err)
+		}
+		if consumerFilter != "" && !subjectMatches(consumerFilter, msg.Subject) {
+			return nil, nil
+		}
+
+		d.StreamListItem(ctx, newStreamMessage(stream, filter, msg.Subject, msg.Sequence, msg.Time, directGetHeaders(msg.Header), msg.Data))
+		return nil, nil
+	}
+
+	var opts []nats.SubOpt
+	switch {
+	case first > 0:
+		opts = append(opts, nats.StartSequence(first))
+	case !from.IsZero():
+		opts = append(opts, nats.StartTime(from))
+	default:
+		opts = append(opts, nats.DeliverAll())
+	}
+
+	err = eachMessage(ctx, js, stream, consumerFilter, opts, func(msg *nats.Msg, meta *nats.MsgMetadata) bool {
+		seq := meta.Sequence.Stream
+		// Postgres only has microseconds, so a message is still within a
+		// bound taken from its own truncated time.
+		if seq > last || (!to.IsZero() && meta.Timestamp.Truncate(time.Microsecond).After(to)) {
+			return false
+		}
+		if (first == 0 || seq >= first) && (from.IsZero() || !meta.Timestamp.Before(from)) {
+			d.StreamListItem(ctx, newStreamMessage(stream, filter, msg.Subject, seq, meta.Timestamp, msg.Header, msg.Data))
+		}
+
+		return seq < last && d.QueryStatus.RowsRemaining(ctx) > 0
+	})
+	if err != nil {
+		return nil, streamError(stream, err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,219 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+)
+
+var streamMessagesTestColumns = []string{"stream", "seq", "subject", "subject_filter", "time", "headers", "size", "payload", "payload_json"}
+
+// createMessageStream creates a direct get stream holding a JSON message with
+// headers followed by a binary one, and removes it when the test ends.
+func createMessageStream(t *testing.T, name string) nats.JetStreamContext {
+	t.Helper()
+
+	nc, err := nats.Connect(testServer.ClientURL(), nats.UserInfo("app", "app"))
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatalf("jetstream failed: %v", err)
+	}
+
+	_, err = js.AddStream(&nats.StreamConfig{Name: name, Subjects: []string{name + ".>"}, Storage: nats.MemoryStorage, AllowDirect: true})
+	if err != nil {
+		t.Fatalf("add stream failed: %v", err)
+	}
+	t.Cleanup(func() { js.DeleteStream(name) })
+
+	msg := nats.NewMsg(name + ".json")
+	msg.Header.Set("Trace", "abc")
+	msg.Data = []byte(`{"ok":true}`)
+	if _, err := js.PublishMsg(msg); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if _, err := js.Publish(name+".binary", []byte{0xff, 0xfe}); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	return js
+}
+
+// requireIntRow returns the first row whose column is value. Messages are
+// looked up by sequence as rows are not necessarily returned in stream order.
+func requireIntRow(t *testing.T, rows []testRow, column string, value int64) testRow {
+	t.Helper()
+
+	for _, r := range rows {
+		if r[column].GetIntValue() == value {
+			return r
+		}
+	}
+	t.Fatalf("no row with %s %d in %d rows", column, value, len(rows))
+	return nil
+}
+
+func TestStreamMessagesList(t *testing.T) {
+	rows := query(t, "stream_messages", streamMessagesTestColumns, map[string]string{"stream": "ORDERS"})
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	for _, r := range rows {
+		assertString(t, r, "stream", "ORDERS")
+		assertString(t, r, "subject", "orders.new")
+		if r["time"].GetTimestampValue() == nil {
+			t.Errorf("expected time to be set")
+		}
+	}
+
+	r := requireIntRow(t, rows, "seq", 1)
+	assertString(t, r, "payload", `{"id":0}`)
+	assertInt(t, r, "size", 8)
+	if v := string(r["payload_json"].GetJsonValue()); v != `{"id":0}` {
+		t.Errorf("expected payload_json to be the payload, got %q", v)
+	}
+}
+
+func TestStreamMessagesSeqRange(t *testing.T) {
+	stream := qual("stream", "=", stringValue("ORDERS"))
+
+	rows := queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, qual("seq", ">", intValue(1)))
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	requireIntRow(t, rows, "seq", 2)
+	requireIntRow(t, rows, "seq", 3)
+
+	rows = queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, qual("seq", ">=", intValue(2)), qual("seq", "<", intValue(3)))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertInt(t, rows[0], "seq", 2)
+
+	rows = queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, qual("seq", "=", intValue(3)))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertString(t, rows[0], "payload", `{"id":2}`)
+
+	rows = queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, qual("seq", ">", intValue(3)))
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+
+	// Nothing is read for ranges below the first sequence.
+	for _, q := range []*proto.Qual{qual("seq", "<", intValue(1)), qual("seq", "<=", intValue(0)), qual("seq", "=", intValue(0))} {
+		rows = queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, q)
+		if len(rows) != 0 {
+			t.Fatalf("expected no rows for seq %s %d, got %d", q.GetStringValue(), q.Value.GetInt64Value(), len(rows))
+		}
+	}
+}
+
+func TestStreamMessagesTime(t *testing.T) {
+	stream := qual("stream", "=", stringValue("ORDERS"))
+
+	rows := queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, qual("time", "<", timestampValue(time.Now())))
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	rows = queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, qual("time", ">", timestampValue(time.Now().Add(time.Hour))))
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
+
+func TestStreamMessagesTimeFromRow(t *testing.T) {
+	stream := qual("stream", "=", stringValue("ORDERS"))
+
+	rows := queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, qual("seq", "=", intValue(2)))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	// Postgres sends the time back with microseconds only.
+	ts := rows[0]["time"].GetTimestampValue().AsTime().Truncate(time.Microsecond)
+
+	rows = queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, qual("time", "=", timestampValue(ts)))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertInt(t, rows[0], "seq", 2)
+
+	rows = queryQuals(t, "stream_messages", streamMessagesTestColumns, stream, qual("time", "<=", timestampValue(ts)))
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	requireIntRow(t, rows, "seq", 1)
+	requireIntRow(t, rows, "seq", 2)
+}
+
+func TestStreamMessagesSubject(t *testing.T) {
+	rows := query(t, "stream_messages", streamMessagesTestColumns, map[string]string{"stream": "ORDERS", "subject_filter": "orders.*"})
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	assertString(t, rows[0], "subject_filter", "orders.*")
+
+	rows = query(t, "stream_messages", streamMessagesTestColumns, map[string]string{"stream": "ORDERS", "subject": "orders.shipped"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+
+	rows = query(t, "stream_messages", streamMessagesTestColumns, map[string]string{"stream": "ORDERS", "subject": "orders.new", "subject_filter": "shipments.>"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
+
+func TestStreamMessagesLimit(t *testing.T) {
+	rows := queryLimit(t, "stream_messages", streamMessagesTestColumns, 2, qual("stream", "=", stringValue("ORDERS")))
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+}
+
+func TestStreamMessagesPayloads(t *testing.T) {
+	createMessageStream(t, "MESSAGES")
+
+	rows := query(t, "stream_messages", streamMessagesTestColumns, map[string]string{"stream": "MESSAGES"})
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	r := requireRow(t, rows, "subject", "MESSAGES.json")
+	if v := string(r["headers"].GetJsonValue()); v != `{"Trace":["abc"]}` {
+		t.Errorf("expected headers to be set, got %q", v)
+	}
+
+	r = requireRow(t, rows, "subject", "MESSAGES.binary")
+	assertInt(t, r, "size", 2)
+	assertString(t, r, "payload", "")
+	if v := string(r["payload_json"].GetJsonValue()); v != "" && v != "null" {
+		t.Errorf("expected no payload_json, got %q", v)
+	}
+
+	// A single sequence is read with a direct get.
+	rows = queryQuals(t, "stream_messages", streamMessagesTestColumns, qual("stream", "=", stringValue("MESSAGES")), qual("seq", "=", intValue(1)))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertString(t, rows[0], "subject", "MESSAGES.json")
+	if v := string(rows[0]["headers"].GetJsonValue()); v != `{"Trace":["abc"]}` {
+		t.Errorf("expected headers to be set, got %q", v)
+	}
+}
+
+func TestStreamMessagesMissing(t *testing.T) {
+	rows := query(t, "stream_messages", streamMessagesTestColumns, map[string]string{"stream": "MISSING"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
@@ -0,0 +1,138 @@
+package nats
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func streamReplicas() *plugin.Table {
+	return &plugin.Table{
+		Name:        "stream_replicas",
+		Description: "The replicas of every stream and their health",
+		List: &plugin.ListConfig{
+			KeyColumns: plugin.OptionalColumns([]string{"stream"}),
+			Hydrate:    listStreamReplicas,
+		},
+		Columns: []*plugin.Column{
+			{Name: "stream", Type: proto.ColumnType_STRING, Transform: transform.FromField("Stream")},
+			{Name: "cluster", Type: proto.ColumnType_STRING, Transform: transform.FromField("Cluster")},
+			{Name: "raft_group", Type: proto.ColumnType_STRING, Transform: transform.FromField("RaftGroup")},
+			{Name: "leader", Type: proto.ColumnType_STRING, Transform: transform.FromField("Leader")},
+			{Name: "peer", Type: proto.ColumnType_STRING, Transform: transform.FromField("Peer")},
+			{Name: "is_leader", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IsLeader")},
+			{Name: "current", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Current")},
+			{Name: "offline", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Offline")},
+			{Name: "active", Type: proto.ColumnType_INT, Transform: transform.FromField("Active")},
+			{Name: "lag", Type: proto.ColumnType_INT, Transform: transform.FromField("Lag")},
+		},
+	}
+}
+
+// StreamReplica is one replica of a stream. The leader is always current and
+// has no lag. Active is how long ago a follower was last heard from. When the
+// stream has no leader, Leader is empty and every replica is a follower.
+// Streams of servers outside a cluster have no replicas.
+type StreamReplica struct {
+	Stream    string        `json:"stream"`
+	Cluster   string        `json:"cluster"`
+	RaftGroup string        `json:"raft_group"`
+	Leader    string        `json:"leader"`
+	Peer      string        `json:"peer"`
+	IsLeader  bool          `json:"is_leader"`
+	Current   bool          `json:"current"`
+	Offline   bool          `json:"offline"`
+	Active    time.Duration `json:"active"`
+	Lag       uint64        `json:"lag"`
+}
+
+// streamClusterInfo is the cluster part of a stream info response, including
+// the raft group that newer servers report.
+type streamClusterInfo struct {
+	api.JSApiResponse
+	Config  api.StreamConfig `json:"config"`
+	Cluster *struct {
+		api.ClusterInfo
+		RaftGroup string `json:"raft_group"`
+	} `json:"cluster"`
+}
+
+func listStreamReplicas(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	manager, err := getManager(d)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := GetConfig(d.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	streams := qualStrings(d, "stream")
+	if streams == nil {
+		streams, err = manager.StreamNames(nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	load := func(stream string) ([]StreamReplica, error) {
+		var info streamClusterInfo
+		err := requestStreamInfo(ctx, manager.NatsConn(), stream, api.JSApiStreamInfoRequest{}, &info)
+		if isNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, streamError(stream, err)
+		}
+
+		c := info.Cluster
+		if c == nil {
+			return nil, nil
+		}
+
+		replica := StreamReplica{
+			Stream:    info.Config.Name,
+			Cluster:   c.Name,
+			RaftGroup: c.RaftGroup,
+			Leader:    c.Leader,
+		}
+
+		// A stream that lost its leader still lists its replicas, which is
+		// when their health matters most.
+		var replicas []StreamReplica
+		if c.Leader != "" {
+			leader := replica
+			leader.Peer = c.Leader
+			leader.IsLeader = true
+			leader.Current = true
+			replicas = append(replicas, leader)
+		}
+
+		for _, peer := range c.Replicas {
+			follower := replica
+			follower.Peer = peer.Name
+			follower.Current = peer.Current
+			follower.Offline = peer.Offline
+			follower.Active = peer.Active
+			follower.Lag = peer.Lag
+			replicas = append(replicas, follower)
+		}
+
+		return replicas, nil
+	}
+
+	err = fanOut(ctx, config.maxConcurrency(), streams, load, func(r StreamReplica) bool {
+		d.StreamListItem(ctx, r)
+		return d.QueryStatus.RowsRemaining(ctx) > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,177 @@
+package nats
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+var streamReplicasTestColumns = []string{"stream", "cluster", "raft_group", "leader", "peer", "is_leader", "current", "offline", "active", "lag"}
+
+const testJetStreamClusterConfig = `
+server_name: %s
+listen: 127.0.0.1:-1
+jetstream {
+  store_dir: %q
+}
+cluster {
+  name: js-cluster
+  listen: 127.0.0.1:%d
+  routes: [%s]
+}
+`
+
+// runJetStreamCluster starts a JetStream cluster of n servers.
+func runJetStreamCluster(t *testing.T, n int) []*server.Server {
+	t.Helper()
+
+	var ports []int
+	var routes []string
+	for i := 0; i < n; i++ {
+		port := freePort(t)
+		ports = append(ports, port)
+		routes = append(routes, fmt.Sprintf("nats-route://127.0.0.1:%d", port))
+	}
+
+	var servers []*server.Server
+	for i, port := range ports {
+		name := fmt.Sprintf("js-%d", i)
+		servers = append(servers, runServer(t, fmt.Sprintf(testJetStreamClusterConfig, name, t.TempDir(), port, strings.Join(routes, ", "))))
+	}
+
+	return servers
+}
+
+// addReplicatedStream adds an R3 stream named REPLICATED through s once the
+// cluster has elected a meta leader, which it needs before streams can be added.
+func addReplicatedStream(t *testing.T, s *server.Server) nats.JetStreamContext {
+	t.Helper()
+
+	nc, err := nats.Connect(s.ClientURL())
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatalf("jetstream failed: %v", err)
+	}
+
+	waitForTimeout(t, "the meta leader", 20*time.Second, func() bool {
+		_, err = js.AddStream(&nats.StreamConfig{Name: "REPLICATED", Subjects: []string{"replicated.>"}, Replicas: 3})
+		return err == nil
+	})
+
+	return js
+}
+
+func TestStreamReplicasSingleServer(t *testing.T) {
+	rows := query(t, "stream_replicas", streamReplicasTestColumns, map[string]string{"stream": "ORDERS"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no replicas outside a cluster, got %d rows", len(rows))
+	}
+}
+
+func TestStreamReplicasCluster(t *testing.T) {
+	servers := runJetStreamCluster(t, 3)
+	addReplicatedStream(t, servers[0])
+
+	config := fmt.Sprintf("urls = %q", servers[0].ClientURL())
+
+	var rows []testRow
+	waitFor(t, "replicas", func() bool {
+		rows = queryConfig(t, config, "stream_replicas", streamReplicasTestColumns)
+		return len(rows) == 3 && findRow(rows, "leader", "") == nil
+	})
+
+	leaders := 0
+	for _, r := range rows {
+		assertString(t, r, "stream", "REPLICATED")
+		assertString(t, r, "cluster", "js-cluster")
+		if r["is_leader"].GetBoolValue() {
+			leaders++
+			assertString(t, r, "peer", r["leader"].GetStringValue())
+		}
+	}
+	if leaders != 1 {
+		t.Errorf("expected 1 leader, got %d", leaders)
+	}
+
+	for _, s := range servers {
+		requireRow(t, rows, "peer", s.Name())
+	}
+}
+
+func TestStreamReplicasNoLeader(t *testing.T) {
+	servers := runJetStreamCluster(t, 5)
+
+	js := addReplicatedStream(t, servers[0])
+
+	var info *nats.StreamInfo
+	waitFor(t, "stream leader", func() bool {
+		var err error
+		info, err = js.StreamInfo("REPLICATED")
+		return err == nil && info.Cluster != nil && info.Cluster.Leader != "" && len(info.Cluster.Replicas) == 2
+	})
+
+	// Stopping the leader and one follower leaves the last follower unable
+	// to elect a new leader.
+	stopped := map[string]bool{info.Cluster.Leader: true, info.Cluster.Replicas[0].Name: true}
+	var running []*server.Server
+	for _, s := range servers {
+		if stopped[s.Name()] {
+			s.Shutdown()
+		} else {
+			running = append(running, s)
+		}
+	}
+
+	nc, err := nats.Connect(running[0].ClientURL())
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer nc.Close()
+
+	js, err = nc.JetStream()
+	if err != nil {
+		t.Fatalf("jetstream failed: %v", err)
+	}
+
+	waitForTimeout(t, "the stream to lose its leader", 30*time.Second, func() bool {
+		info, err = js.StreamInfo("REPLICATED")
+		return err == nil && info.Cluster != nil && info.Cluster.Leader == ""
+	})
+
+	rows := queryConfig(t, fmt.Sprintf("urls = %q", running[0].ClientURL()), "stream_replicas", streamReplicasTestColumns)
+	if len(rows) != len(info.Cluster.Replicas) {
+		t.Fatalf("expected %d rows, got %d", len(info.Cluster.Replicas), len(rows))
+	}
+
+	offline := 0
+	for _, r := range rows {
+		assertString(t, r, "stream", "REPLICATED")
+		assertString(t, r, "leader", "")
+		assertBool(t, r, "is_leader", false)
+		if r["offline"].GetBoolValue() {
+			offline++
+		}
+	}
+	for name := range stopped {
+		requireRow(t, rows, "peer", name)
+	}
+	if offline == 0 {
+		t.Errorf("expected the stopped peers to be offline")
+	}
+}
+
+func TestStreamReplicasMissing(t *testing.T) {
+	rows := query(t, "stream_replicas", streamReplicasTestColumns, map[string]string{"stream": "MISSING"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
@@ -0,0 +1,131 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func streamSubjects() *plugin.Table {
+	return &plugin.Table{
+		Name:        "stream_subjects",
+		Description: "The subjects of a stream and how many messages each holds",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "stream", Require: plugin.Required},
+				{Name: "subject", Require: plugin.Optional},
+				{Name: "subject_filter", Require: plugin.Optional},
+			},
+			Hydrate: listStreamSubjects,
+		},
+		Columns: []*plugin.Column{
+			{Name: "stream", Type: proto.ColumnType_STRING, Transform: transform.FromField("Stream")},
+			{Name: "subject", Type: proto.ColumnType_STRING, Transform: transform.FromField("Subject")},
+			{Name: "subject_filter", Type: proto.ColumnType_STRING, Transform: transform.FromField("SubjectFilter")},
+			{Name: "messages", Type: proto.ColumnType_INT, Transform: transform.FromField("Messages")},
+		},
+	}
+}
+
+// StreamSubject is a subject holding messages in a stream.
+type StreamSubject struct {
+	Stream        string `json:"stream"`
+	Subject       string `json:"subject"`
+	SubjectFilter string `json:"subject_filter"`
+	Messages      uint64 `json:"messages"`
+}
+
+// jsAPIResponse is a JetStream API response that may hold an error.
+type jsAPIResponse interface {
+	ToError() error
+}
+
+// requestStreamInfo sends req to the stream info API of stream and decodes the
+// answer into resp, which lets callers read fields jsm.go does not know about.
+func requestStreamInfo(ctx context.Context, nc *nats.Conn, stream string, req api.JSApiStreamInfoRequest, resp jsAPIResponse) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jetStreamTimeout)
+	defer cancel()
+
+	msg, err := nc.RequestWithContext(ctx, fmt.Sprintf(api.JSApiStreamInfoT, stream), body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(msg.Data, resp); err != nil {
+		return err
+	}
+
+	return resp.ToError()
+}
+
+// eachStreamSubject calls cb for every subject of stream matching filter until
+// it returns false. Stream info only holds a page of subjects, so the pages
+// are requested one at a time.
+func eachStreamSubject(ctx context.Context, nc *nats.Conn, stream, filter string, cb func(string, uint64) bool) error {
+	req := api.JSApiStreamInfoRequest{SubjectsFilter: filter}
+
+	for {
+		var resp api.JSApiStreamInfoResponse
+		if err := requestStreamInfo(ctx, nc, stream, req, &resp); err != nil {
+			return err
+		}
+
+		subjects := resp.State.Subjects
+		for _, subject := range sortedKeys(subjects) {
+			if !cb(subject, subjects[subject]) {
+				return nil
+			}
+		}
+
+		req.Offset += len(subjects)
+		if len(subjects) == 0 || req.Offset >= resp.Total {
+			return nil
+		}
+	}
+}
+
+// listStreamSubjects lists the subjects of a stream.
+func listStreamSubjects(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	manager, err := getManager(d)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := d.KeyColumnQuals["stream"].GetStringValue()
+	filter := d.KeyColumnQuals["subject_filter"].GetStringValue()
+
+	infoFilter, ok := narrowSubject(d, "subject", "subject_filter")
+	if !ok {
+		return nil, nil
+	}
+	if infoFilter == "" {
+		infoFilter = ">"
+	}
+
+	err = eachStreamSubject(ctx, manager.NatsConn(), stream, infoFilter, func(subject string, msgs uint64) bool {
+		d.StreamListItem(ctx, StreamSubject{
+			Stream:        stream,
+			Subject:       subject,
+			SubjectFilter: filter,
+			Messages:      msgs,
+		})
+
+		return d.QueryStatus.RowsRemaining(ctx) > 0
+	})
+	if err != nil {
+		return nil, streamError(stream, err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,63 @@
+package nats
+
+import "testing"
+
+var streamSubjectsTestColumns = []string{"stream", "subject", "subject_filter", "messages"}
+
+func TestStreamSubjectsList(t *testing.T) {
+	rows := query(t, "stream_subjects", streamSubjectsTestColumns, map[string]string{"stream": "ORDERS"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "stream", "ORDERS")
+	assertString(t, rows[0], "subject", "orders.new")
+	assertInt(t, rows[0], "messages", 3)
+}
+
+func TestStreamSubjectsFilter(t *testing.T) {
+	js := createMessageStream(t, "SUBJECTS")
+	for _, subject := range []string{"SUBJECTS.a.1", "SUBJECTS.a.2", "SUBJECTS.a.2", "SUBJECTS.b.1"} {
+		if _, err := js.Publish(subject, nil); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+	}
+
+	rows := query(t, "stream_subjects", streamSubjectsTestColumns, map[string]string{"stream": "SUBJECTS"})
+	if len(rows) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(rows))
+	}
+
+	rows = query(t, "stream_subjects", streamSubjectsTestColumns, map[string]string{"stream": "SUBJECTS", "subject_filter": "SUBJECTS.a.*"})
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	assertString(t, rows[0], "subject_filter", "SUBJECTS.a.*")
+	assertInt(t, requireRow(t, rows, "subject", "SUBJECTS.a.2"), "messages", 2)
+
+	rows = query(t, "stream_subjects", streamSubjectsTestColumns, map[string]string{"stream": "SUBJECTS", "subject": "SUBJECTS.b.1"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	rows = query(t, "stream_subjects", streamSubjectsTestColumns, map[string]string{"stream": "SUBJECTS", "subject": "SUBJECTS.b.1", "subject_filter": "SUBJECTS.a.>"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
+
+func TestStreamSubjectsLimit(t *testing.T) {
+	createMessageStream(t, "LIMITED")
+
+	rows := queryLimit(t, "stream_subjects", streamSubjectsTestColumns, 1, qual("stream", "=", stringValue("LIMITED")))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+}
+
+func TestStreamSubjectsMissing(t *testing.T) {
+	rows := query(t, "stream_subjects", streamSubjectsTestColumns, map[string]string{"stream": "MISSING"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
@@ -0,0 +1,160 @@
+package nats
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strconv"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// subszLimit asks servers for all their subscriptions at once. Servers list
+// subscriptions in map order, which changes between requests, so offset paging
+// would skip and repeat rows. They build the full list for every request
+// anyway and only slice it to the limit.
+const subszLimit = math.MaxInt32
+
+func subszInfo() *plugin.Table {
+	return &plugin.Table{
+		Name:        "subsz_info",
+		Description: "The subscription statistics of every monitored server, or its subscriptions when detail or test_subject is set",
+		List: &plugin.ListConfig{
+			KeyColumns: append(serverKeyColumns(), plugin.OptionalColumns([]string{"account", "detail", "test_subject"})...),
+			Hydrate:    listSubszInfos,
+		},
+		Columns: []*plugin.Column{
+			{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerID")},
+			{Name: "server_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("ServerName")},
+			{Name: "num_subscriptions", Type: proto.ColumnType_INT, Transform: transform.FromField("Stats.NumSubs")},
+			{Name: "num_cache", Type: proto.ColumnType_INT, Transform: transform.FromField("Stats.NumCache")},
+			{Name: "num_inserts", Type: proto.ColumnType_INT, Transform: transform.FromField("Stats.NumInserts")},
+			{Name: "num_removes", Type: proto.ColumnType_INT, Transform: transform.FromField("Stats.NumRemoves")},
+			{Name: "num_matches", Type: proto.ColumnType_INT, Transform: transform.FromField("Stats.NumMatches")},
+			{Name: "cache_hit_rate", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Stats.CacheHitRate")},
+			{Name: "max_fanout", Type: proto.ColumnType_INT, Transform: transform.FromField("Stats.MaxFanout")},
+			{Name: "avg_fanout", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Stats.AvgFanout")},
+			{Name: "detail", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Detail")},
+			{Name: "test_subject", Type: proto.ColumnType_STRING, Transform: transform.FromField("TestSubject")},
+			{Name: "account", Type: proto.ColumnType_STRING, Transform: transform.FromField("Account")},
+			{Name: "subject", Type: proto.ColumnType_STRING, Transform: transform.FromField("Subject")},
+			{Name: "queue", Type: proto.ColumnType_STRING, Transform: transform.FromField("Queue")},
+			{Name: "sid", Type: proto.ColumnType_STRING, Transform: transform.FromField("Sid")},
+			{Name: "msgs", Type: proto.ColumnType_INT, Transform: transform.FromField("Msgs")},
+			{Name: "max", Type: proto.ColumnType_INT, Transform: transform.FromField("Max")},
+			{Name: "cid", Type: proto.ColumnType_INT, Transform: transform.FromField("Cid")},
+			{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
+		},
+	}
+}
+
+// SubszInfo is the subscription statistics of a server, or one of its
+// subscriptions with the statistics repeated when the query asks for detail.
+// Servers only match test_subject against subscriptions, so setting it
+// implies detail.
+type SubszInfo struct {
+	server.SubDetail
+	ServerID    string              `json:"server_id"`
+	ServerName  string              `json:"server_name"`
+	Stats       server.SublistStats `json:"stats"`
+	Detail      bool                `json:"detail"`
+	TestSubject string              `json:"test_subject"`
+	SourceURL   string              `json:"source_url"`
+	Error       string              `json:"error"`
+}
+
+func listSubszInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	monitor, err := getMonitor(d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := server.SubszOptions{Limit: subszLimit}
+	if q := d.KeyColumnQuals["account"]; q != nil {
+		opts.Account = q.GetStringValue()
+	}
+	if q := d.KeyColumnQuals["test_subject"]; q != nil {
+		opts.Test = q.GetStringValue()
+	}
+	if q := d.KeyColumnQuals["detail"]; q != nil {
+		opts.Subscriptions = q.GetBoolValue()
+	}
+	opts.Subscriptions = opts.Subscriptions || opts.Test != ""
+
+	results, err := fetchServers[server.Subsz](ctx, monitor, subszRequest(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	servers := lookupServers(ctx, monitor, results)
+
+	for _, r := range results {
+		// The HTTP endpoint leaves out the server ID when it has no
+		// subscriptions to list.
+		if r.Value.ID == "" && r.Err == nil {
+			r.Value.ID = servers.id(r.Target)
+		}
+		name := servers.name(r.Value.ID)
+		if !matchesServerQuals(d, r.Value.ID, name) {
+			continue
+		}
+
+		if r.Err != nil {
+			d.StreamListItem(ctx, SubszInfo{SourceURL: r.URL, Error: errorString(r.Err)})
+			continue
+		}
+
+		row := SubszInfo{
+			SubDetail:   server.SubDetail{Account: opts.Account},
+			ServerID:    r.Value.ID,
+			ServerName:  name,
+			Detail:      opts.Subscriptions,
+			TestSubject: opts.Test,
+			SourceURL:   r.URL,
+		}
+		if r.Value.SublistStats != nil {
+			row.Stats = *r.Value.SublistStats
+		}
+
+		if !opts.Subscriptions {
+			d.StreamListItem(ctx, row)
+			continue
+		}
+
+		for _, sub := range r.Value.Subs {
+			row.SubDetail = sub
+			d.StreamListItem(ctx, row)
+
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// subszRequest builds a subsz request for both monitoring sources.
+func subszRequest(opts server.SubszOptions) monitoringRequest {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(opts.Limit))
+	if opts.Subscriptions {
+		query.Set("subs", "true")
+	}
+	if opts.Account != "" {
+		query.Set("acc", opts.Account)
+	}
+	if opts.Test != "" {
+		query.Set("test", opts.Test)
+	}
+
+	return monitoringRequest{
+		Endpoint: "subsz",
+		Query:    query,
+		Options:  server.SubszEventOptions{SubszOptions: opts},
+	}
+}
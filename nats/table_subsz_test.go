@@ -0,0 +1,93 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+var subszTestColumns = []string{"server_id", "server_name", "num_subscriptions", "detail", "test_subject", "account", "subject", "queue", "cid", "source_url", "error"}
+
+// subscribeClient subscribes an APP connection to each subject, in queue
+// group queue when it is set.
+func subscribeClient(t *testing.T, queue string, subjects ...string) {
+	t.Helper()
+
+	nc, err := nats.Connect(testServer.ClientURL(), nats.UserInfo("app", "app"))
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	for _, subject := range subjects {
+		if _, err := nc.QueueSubscribeSync(subject, queue); err != nil {
+			t.Fatalf("subscribe failed: %v", err)
+		}
+	}
+	if err := nc.Flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+}
+
+func TestSubszInfoStats(t *testing.T) {
+	subscribeClient(t, "", "subsz.stats")
+
+	rows := query(t, "subsz_info", subszTestColumns, nil)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "server_name", "test-server")
+	assertString(t, rows[0], "subject", "")
+	assertString(t, rows[0], "source_url", testMonitoringURL())
+	if rows[0]["num_subscriptions"].GetIntValue() < 1 {
+		t.Errorf("expected subscriptions to be counted")
+	}
+}
+
+func TestSubszInfoTestSubject(t *testing.T) {
+	subscribeClient(t, "", "subsz.*.1", "subsz.eu.2")
+	subscribeClient(t, "workers", "subsz.eu.>")
+
+	rows := query(t, "subsz_info", subszTestColumns, map[string]string{"test_subject": "subsz.eu.1"})
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	r := requireRow(t, rows, "subject", "subsz.*.1")
+	assertString(t, r, "account", "APP")
+	assertString(t, r, "test_subject", "subsz.eu.1")
+	assertBool(t, r, "detail", true)
+	if r["cid"].GetIntValue() == 0 {
+		t.Errorf("expected a cid")
+	}
+
+	r = requireRow(t, rows, "subject", "subsz.eu.>")
+	assertString(t, r, "queue", "workers")
+}
+
+func TestSubszInfoDetail(t *testing.T) {
+	subscribeClient(t, "", "subsz.detail.1", "subsz.detail.2")
+
+	rows := queryQuals(t, "subsz_info", subszTestColumns,
+		qual("detail", "=", boolValue(true)), qual("account", "=", stringValue("APP")))
+
+	for _, subject := range []string{"subsz.detail.1", "subsz.detail.2"} {
+		r := requireRow(t, rows, "subject", subject)
+		assertString(t, r, "account", "APP")
+		assertBool(t, r, "detail", true)
+	}
+}
+
+func TestSubszInfoSystemSource(t *testing.T) {
+	subscribeClient(t, "", "subsz.system")
+
+	rows := queryConnection(t, testSystemConnection, "subsz_info", subszTestColumns, qual("test_subject", "=", stringValue("subsz.system")))
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "subject", "subsz.system")
+}
@@ -2,10 +2,6 @@ package nats
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"time"
 
 	"github.com/nats-io/nats-server/v2/server"
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
@@ -18,11 +14,8 @@ func varzInfo() *plugin.Table {
 		Name:        "varz_info",
 		Description: "The varz info",
 		List: &plugin.ListConfig{
-			Hydrate: listVarzInfos,
-		},
-		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("server_name"),
-			Hydrate:    getVarzInfo,
+			KeyColumns: serverKeyColumns(),
+			Hydrate:    listVarzInfos,
 		},
 		Columns: []*plugin.Column{
 			{Name: "server_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID")},
@@ -77,70 +70,41 @@ func varzInfo() *plugin.Table {
 			{Name: "trusted_operators_jwt", Type: proto.ColumnType_STRING, Transform: transform.FromField("TrustedOperatorsJwt")},
 			{Name: "system_account", Type: proto.ColumnType_STRING, Transform: transform.FromField("SystemAccount")},
 			{Name: "pinned_account_fails", Type: proto.ColumnType_INT, Transform: transform.FromField("PinnedAccountFail")},
+			{Name: "source_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceURL")},
+			{Name: "error", Type: proto.ColumnType_STRING, Transform: transform.FromField("Error")},
 		},
 	}
 }
 
-func listVarzInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
-	if err != nil {
-		return nil, err
-	}
-
-	client := http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	url := fmt.Sprintf("%s/varz", String(config.MonitoringURL))
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	var varz server.Varz
-	err = json.NewDecoder(resp.Body).Decode(&varz)
-	if err != nil {
-		return nil, err
-	}
-
-	d.StreamListItem(ctx, &varz)
-
-	return nil, nil
+// VarzInfo is the varz of a single server, or the error fetching it.
+type VarzInfo struct {
+	server.Varz
+	SourceURL string `json:"source_url"`
+	Error     string `json:"error"`
 }
 
-func getVarzInfo(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	config, err := GetConfig(d.Connection)
+func listVarzInfos(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	monitor, err := getMonitor(d)
 	if err != nil {
 		return nil, err
 	}
 
-	client := http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	url := fmt.Sprintf("%s/varz", String(config.MonitoringURL))
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	results, err := fetchServers[server.Varz](ctx, monitor, monitoringRequest{Endpoint: "varz"})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
+	for _, r := range results {
+		if !matchesServerQuals(d, r.Value.ID, r.Value.Name) {
+			continue
+		}
 
-	var varz server.Varz
-	err = json.NewDecoder(resp.Body).Decode(&varz)
-	if err != nil {
-		return nil, err
+		d.StreamListItem(ctx, VarzInfo{
+			Varz:      r.Value,
+			SourceURL: r.URL,
+			Error:     errorString(r.Err),
+		})
 	}
 
-	return varz, nil
+	return nil, nil
 }
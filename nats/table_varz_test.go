@@ -0,0 +1,49 @@
+package nats
+
+import (
+	"net"
+	"testing"
+)
+
+var varzColumns = []string{"server_id", "server_name", "version", "port", "source_url", "error"}
+
+func TestVarzInfoList(t *testing.T) {
+	rows := query(t, "varz_info", varzColumns, nil)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "server_name", "test-server")
+	assertInt(t, rows[0], "port", int64(testServer.Addr().(*net.TCPAddr).Port))
+	assertString(t, rows[0], "source_url", testMonitoringURL())
+	assertString(t, rows[0], "error", "")
+}
+
+func TestVarzInfoFilter(t *testing.T) {
+	rows := query(t, "varz_info", varzColumns, map[string]string{"server_name": "test-server"})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	assertString(t, rows[0], "server_name", "test-server")
+
+	rows = query(t, "varz_info", varzColumns, map[string]string{"server_id": testServer.ID()})
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	rows = query(t, "varz_info", varzColumns, map[string]string{"server_name": "other-server"})
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows, got %d", len(rows))
+	}
+}
+
+func TestVarzInfoSystemSource(t *testing.T) {
+	rows := queryConnection(t, testSystemConnection, "varz_info", varzColumns)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertString(t, rows[0], "server_id", testServer.ID())
+	assertString(t, rows[0], "server_name", "test-server")
+}